@@ -0,0 +1,79 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"github.com/jasoet/vault-client/pkg/client"
+	"io"
+	"os"
+	"strings"
+)
+
+// vault-kv-scan walk a KV version 2 mount and write weak, duplicated, misplaced and stale secrets as JSON or SARIF.
+// Vault address and token are read from VAULT_ADDR and VAULT_TOKEN, secret values are never written.
+func main() {
+	mount := flag.String("mount", "secret", "KV version 2 mount path")
+	path := flag.String("path", "", "path inside the mount to scan recursively, empty scan the whole mount")
+	format := flag.String("format", "json", "output format, json or sarif")
+	output := flag.String("output", "", "output file, empty write to stdout")
+	minEntropy := flag.Float64("min-entropy", 0, "minimum password entropy in bits, zero use the library default")
+	staleDays := flag.Int("stale-days", 0, "report secrets not updated for more days, zero use the library default")
+	passwordFields := flag.String("password-fields", "", "comma separated field names checked for weak passwords, empty use the library default")
+	failOnFindings := flag.Bool("fail-on-findings", false, "exit with status 1 when any finding is reported")
+	flag.Parse()
+
+	if *format != "json" && *format != "sarif" {
+		exit(fmt.Errorf("unknown format %q, use json or sarif", *format))
+	}
+
+	options := client.KVScanOptions{MinEntropy: *minEntropy, StaleDays: *staleDays}
+	if *passwordFields != "" {
+		options.PasswordFields = strings.Split(*passwordFields, ",")
+	}
+
+	kv, err := client.NewKVWithPath(*mount)
+	if err != nil {
+		exit(err)
+	}
+
+	report, err := kv.Scan(*path, options)
+	if err != nil {
+		exit(err)
+	}
+
+	err = write(report, *format, *output)
+	if err != nil {
+		exit(err)
+	}
+
+	if len(report.Failed) > 0 {
+		_, _ = fmt.Fprintf(os.Stderr, "failed to read %v secret(s): %v\n", len(report.Failed), strings.Join(report.Failed, ", "))
+	}
+
+	if *failOnFindings && len(report.Findings) > 0 {
+		_, _ = fmt.Fprintf(os.Stderr, "%v finding(s) reported\n", len(report.Findings))
+		os.Exit(1)
+	}
+}
+
+func write(report *client.KVScanReport, format string, output string) (err error) {
+	var writer io.Writer = os.Stdout
+	if output != "" {
+		file, err := os.Create(output)
+		if err != nil {
+			return err
+		}
+		defer file.Close()
+		writer = file
+	}
+
+	if format == "sarif" {
+		return report.WriteSARIF(writer)
+	}
+	return report.WriteJSON(writer)
+}
+
+func exit(err error) {
+	_, _ = fmt.Fprintf(os.Stderr, "vault-kv-scan: %v\n", err)
+	os.Exit(2)
+}
@@ -0,0 +1,116 @@
+package client
+
+import (
+	"fmt"
+	"github.com/hashicorp/vault/api"
+	"github.com/jasoet/vault-client/pkg/util"
+	"strings"
+)
+
+type auditEngine struct {
+	vaultClient *api.Client
+}
+
+// List return enabled audit devices keyed by path without trailing slash
+func (a auditEngine) List() (devices map[string]AuditDevice, err error) {
+	result, err := a.vaultClient.Logical().Read("sys/audit")
+	if err != nil {
+		return
+	}
+
+	devices = map[string]AuditDevice{}
+	if result == nil {
+		return
+	}
+
+	for path, value := range result.Data {
+		data, ok := value.(map[string]interface{})
+		if !ok {
+			continue
+		}
+
+		device := AuditDevice{}
+		err = util.MapToStruct(data, &device)
+		if err != nil {
+			return nil, err
+		}
+		devices[strings.TrimSuffix(path, "/")] = device
+	}
+	return
+}
+
+func (a auditEngine) Enable(path string, device AuditDevice) (err error) {
+	payload, err := util.StructToMapE(device)
+	if err != nil {
+		return
+	}
+	delete(payload, "path")
+
+	_, err = a.vaultClient.Logical().Write(fmt.Sprintf("sys/audit/%v", path), payload)
+	return
+}
+
+func (a auditEngine) Disable(path string) (err error) {
+	_, err = a.vaultClient.Logical().Delete(fmt.Sprintf("sys/audit/%v", path))
+	return
+}
+
+// Hash return HMAC of input using the salt of audit device on path, as it would appear in its audit log
+func (a auditEngine) Hash(path string, input string) (hash string, err error) {
+	payload := map[string]interface{}{
+		"input": input,
+	}
+	result, err := a.vaultClient.Logical().Write(fmt.Sprintf("sys/audit-hash/%v", path), payload)
+	if err != nil {
+		return
+	}
+
+	if result == nil {
+		err = fmt.Errorf("%v is not found", path)
+		return
+	}
+
+	hash = fmt.Sprint(result.Data["hash"])
+	return
+}
+
+func NewFileAudit(description string, options FileAuditOptions) AuditDevice {
+	return AuditDevice{Type: FileAudit, Description: description, Options: auditOptions(options)}
+}
+
+func NewSyslogAudit(description string, options SyslogAuditOptions) AuditDevice {
+	return AuditDevice{Type: SyslogAudit, Description: description, Options: auditOptions(options)}
+}
+
+func NewSocketAudit(description string, options SocketAuditOptions) AuditDevice {
+	return AuditDevice{Type: SocketAudit, Description: description, Options: auditOptions(options)}
+}
+
+// auditOptions convert typed options to string options, Vault accept audit options as strings only
+func auditOptions(options interface{}) map[string]string {
+	result := map[string]string{}
+	// options is always one of the typed audit options struct, so it can not fail
+	values, _ := util.StructToMapE(options)
+	for key, value := range values {
+		if pointer, ok := value.(*bool); ok {
+			value = *pointer
+		}
+		result[key] = fmt.Sprint(value)
+	}
+	return result
+}
+
+func DefaultAudit() (audit Audit, err error) {
+	vaultClient, err := api.NewClient(api.DefaultConfig())
+	if err != nil {
+		return
+	}
+
+	audit = &auditEngine{vaultClient: vaultClient}
+	return
+}
+
+func NewAudit(vaultClient *api.Client) (audit Audit, err error) {
+	audit = &auditEngine{vaultClient: vaultClient}
+	return
+}
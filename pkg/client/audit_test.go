@@ -0,0 +1,90 @@
+// +build integration
+
+package client_test
+
+import (
+	"github.com/hashicorp/vault/api"
+	. "github.com/jasoet/vault-client/pkg/client"
+	"github.com/stretchr/testify/assert"
+	"os"
+	"strings"
+	"testing"
+)
+
+type auditTestCtx struct {
+	vaultClient *api.Client
+}
+
+func (ctx *auditTestCtx) setup(t *testing.T) {
+	config := &api.Config{Address: os.Getenv("TEST_VAULT_ADDR")}
+	client, err := api.NewClient(config)
+	assert.Nil(t, err)
+
+	client.SetToken(os.Getenv("TEST_VAULT_TOKEN"))
+
+	ctx.vaultClient = client
+}
+
+func TestAudit(t *testing.T) {
+	ctx := new(auditTestCtx)
+	ctx.setup(t)
+
+	engine, err := NewAudit(ctx.vaultClient)
+	assert.Nil(t, err)
+	assert.NotNil(t, engine)
+
+	path := "audit-stdout"
+	_ = engine.Disable(path)
+
+	hmacAccessor := false
+	device := NewFileAudit("audit to stdout", FileAuditOptions{
+		AuditOptions: AuditOptions{Format: "json", HmacAccessor: &hmacAccessor},
+		FilePath:     "stdout",
+	})
+
+	t.Run("file audit should have string options", func(t *testing.T) {
+		assert.Equal(t, FileAudit, device.Type)
+		assert.Equal(t, map[string]string{"file_path": "stdout", "format": "json", "hmac_accessor": "false"}, device.Options)
+	})
+
+	t.Run("should enable and list audit device", func(t *testing.T) {
+		err := engine.Enable(path, device)
+		assert.Nil(t, err)
+
+		devices, err := engine.List()
+		assert.Nil(t, err)
+
+		enabled, ok := devices[path]
+		assert.True(t, ok)
+		assert.Equal(t, FileAudit, enabled.Type)
+		assert.Equal(t, "audit to stdout", enabled.Description)
+		assert.Equal(t, "stdout", enabled.Options["file_path"])
+		assert.Equal(t, path+"/", enabled.Path)
+	})
+
+	t.Run("hash should be stable hmac of the input", func(t *testing.T) {
+		hash, err := engine.Hash(path, "secret-value")
+		assert.Nil(t, err)
+		assert.True(t, strings.HasPrefix(hash, "hmac-sha256:"))
+
+		again, err := engine.Hash(path, "secret-value")
+		assert.Nil(t, err)
+		assert.Equal(t, hash, again)
+
+		other, err := engine.Hash(path, "other-value")
+		assert.Nil(t, err)
+		assert.NotEqual(t, hash, other)
+	})
+
+	t.Run("disabled audit device should not be listed", func(t *testing.T) {
+		err := engine.Disable(path)
+		assert.Nil(t, err)
+
+		devices, err := engine.List()
+		assert.Nil(t, err)
+		assert.NotContains(t, devices, path)
+
+		_, err = engine.Hash(path, "secret-value")
+		assert.NotNil(t, err)
+	})
+}
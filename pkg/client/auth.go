@@ -0,0 +1,97 @@
+package client
+
+import (
+	"fmt"
+	"github.com/hashicorp/vault/api"
+	"github.com/jasoet/vault-client/pkg/util"
+	"strings"
+)
+
+type authEngine struct {
+	vaultClient *api.Client
+}
+
+// ListAuth return enabled auth methods keyed by mount path without trailing slash
+func (a authEngine) ListAuth() (mounts map[string]AuthMount, err error) {
+	result, err := a.vaultClient.Logical().Read("sys/auth")
+	if err != nil {
+		return
+	}
+
+	mounts = map[string]AuthMount{}
+	if result == nil {
+		return
+	}
+
+	for path, value := range result.Data {
+		data, ok := value.(map[string]interface{})
+		if !ok {
+			continue
+		}
+
+		mount := AuthMount{}
+		err = util.MapToStruct(data, &mount)
+		if err != nil {
+			return nil, err
+		}
+		mounts[strings.TrimSuffix(path, "/")] = mount
+	}
+	return
+}
+
+func (a authEngine) EnableAuth(path string, mount AuthMount) (err error) {
+	payload, err := util.StructToMapE(mount)
+	if err != nil {
+		return
+	}
+
+	_, err = a.vaultClient.Logical().Write(fmt.Sprintf("sys/auth/%v", path), payload)
+	return
+}
+
+// DisableAuth revoke all tokens issued by the auth method
+func (a authEngine) DisableAuth(path string) (err error) {
+	_, err = a.vaultClient.Logical().Delete(fmt.Sprintf("sys/auth/%v", path))
+	return
+}
+
+func (a authEngine) ReadAuthTune(path string) (config *AuthMountConfig, err error) {
+	result, err := a.vaultClient.Logical().Read(fmt.Sprintf("sys/auth/%v/tune", path))
+	if err != nil {
+		return
+	}
+
+	if result == nil {
+		err = fmt.Errorf("%v is not found", path)
+		return
+	}
+
+	config = new(AuthMountConfig)
+	err = util.MapToStruct(result.Data, config)
+	return
+}
+
+func (a authEngine) TuneAuth(path string, config AuthMountConfig) (err error) {
+	payload, err := util.StructToMapE(config)
+	if err != nil {
+		return
+	}
+
+	_, err = a.vaultClient.Logical().Write(fmt.Sprintf("sys/auth/%v/tune", path), payload)
+	return
+}
+
+func DefaultAuth() (auth Auth, err error) {
+	vaultClient, err := api.NewClient(api.DefaultConfig())
+	if err != nil {
+		return
+	}
+
+	auth = &authEngine{vaultClient: vaultClient}
+	return
+}
+
+func NewAuth(vaultClient *api.Client) (auth Auth, err error) {
+	auth = &authEngine{vaultClient: vaultClient}
+	return
+}
@@ -0,0 +1,178 @@
+package client
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/hashicorp/vault/api"
+	"github.com/jasoet/vault-client/pkg/util"
+)
+
+type appRoleEngine struct {
+	vaultClient *api.Client
+	path        string
+}
+
+func (a appRoleEngine) Path() string {
+	return a.path
+}
+
+func (a appRoleEngine) Enable() (err error) {
+	data := map[string]interface{}{"type": "approle"}
+	_, err = a.vaultClient.Logical().Write(fmt.Sprintf("/sys/auth/%v", a.path), data)
+	return
+}
+
+func (a appRoleEngine) CreateRole(name string, role AppRoleRole) (err error) {
+	payload, err := util.StructToMapE(role)
+	if err != nil {
+		return
+	}
+
+	_, err = a.vaultClient.Logical().Write(fmt.Sprintf("auth/%v/role/%v", a.path, name), payload)
+	return
+}
+
+func (a appRoleEngine) ReadRole(name string) (role *AppRoleRole, err error) {
+	result, err := a.vaultClient.Logical().Read(fmt.Sprintf("auth/%v/role/%v", a.path, name))
+	if err != nil {
+		return
+	}
+
+	if result == nil {
+		err = fmt.Errorf("%v is not found", name)
+		return
+	}
+
+	role = new(AppRoleRole)
+	err = util.MapToStruct(result.Data, role)
+	return
+}
+
+func (a appRoleEngine) DeleteRole(name string) (err error) {
+	_, err = a.vaultClient.Logical().Delete(fmt.Sprintf("auth/%v/role/%v", a.path, name))
+	return
+}
+
+func (a appRoleEngine) ListRole() (list []string, err error) {
+	result, err := a.vaultClient.Logical().List(fmt.Sprintf("auth/%v/role", a.path))
+	if err != nil || result == nil {
+		return []string{}, nil
+	}
+
+	if val, ok := result.Data["keys"]; ok {
+		list = util.ToArrStr(val.([]interface{}))
+	}
+	return
+}
+
+func (a appRoleEngine) ReadRoleId(name string) (roleId string, err error) {
+	result, err := a.vaultClient.Logical().Read(fmt.Sprintf("auth/%v/role/%v/role-id", a.path, name))
+	if err != nil {
+		return
+	}
+
+	if result == nil {
+		err = fmt.Errorf("%v is not found", name)
+		return
+	}
+
+	roleId = fmt.Sprint(result.Data["role_id"])
+	return
+}
+
+func (a appRoleEngine) GenerateSecretId(roleName string, options AppRoleSecretIdOptions) (secretId *AppRoleSecretId, err error) {
+	payload, err := util.StructToMapE(options)
+	if err != nil {
+		return
+	}
+
+	// Vault expect metadata as JSON encoded string
+	if len(options.Metadata) > 0 {
+		metadata, err := json.Marshal(options.Metadata)
+		if err != nil {
+			return nil, err
+		}
+		payload["metadata"] = string(metadata)
+	}
+
+	result, err := a.vaultClient.Logical().Write(fmt.Sprintf("auth/%v/role/%v/secret-id", a.path, roleName), payload)
+	if err != nil {
+		return
+	}
+
+	if result == nil {
+		err = fmt.Errorf("%v is not found", roleName)
+		return
+	}
+
+	secretId = new(AppRoleSecretId)
+	err = util.MapToStruct(result.Data, secretId)
+	return
+}
+
+func (a appRoleEngine) ListSecretIdAccessor(roleName string) (list []string, err error) {
+	result, err := a.vaultClient.Logical().List(fmt.Sprintf("auth/%v/role/%v/secret-id", a.path, roleName))
+	if err != nil || result == nil {
+		return []string{}, nil
+	}
+
+	if val, ok := result.Data["keys"]; ok {
+		list = util.ToArrStr(val.([]interface{}))
+	}
+	return
+}
+
+func (a appRoleEngine) ReadSecretIdAccessor(roleName string, accessor string) (info *AppRoleSecretIdInfo, err error) {
+	payload := map[string]interface{}{
+		"secret_id_accessor": accessor,
+	}
+	result, err := a.vaultClient.Logical().Write(fmt.Sprintf("auth/%v/role/%v/secret-id-accessor/lookup", a.path, roleName), payload)
+	if err != nil {
+		return
+	}
+
+	if result == nil {
+		err = fmt.Errorf("%v is not found", accessor)
+		return
+	}
+
+	info = new(AppRoleSecretIdInfo)
+	err = util.MapToStruct(result.Data, info)
+	return
+}
+
+func (a appRoleEngine) DestroySecretId(roleName string, secretId string) (err error) {
+	payload := map[string]interface{}{
+		"secret_id": secretId,
+	}
+	_, err = a.vaultClient.Logical().Write(fmt.Sprintf("auth/%v/role/%v/secret-id/destroy", a.path, roleName), payload)
+	return
+}
+
+func (a appRoleEngine) DestroySecretIdAccessor(roleName string, accessor string) (err error) {
+	payload := map[string]interface{}{
+		"secret_id_accessor": accessor,
+	}
+	_, err = a.vaultClient.Logical().Write(fmt.Sprintf("auth/%v/role/%v/secret-id-accessor/destroy", a.path, roleName), payload)
+	return
+}
+
+func DefaultAppRole() (AppRole, error) {
+	vaultClient, err := api.NewClient(api.DefaultConfig())
+	if err != nil {
+		return nil, err
+	}
+	return &appRoleEngine{vaultClient: vaultClient, path: "approle"}, nil
+}
+
+func NewAppRole(vaultClient *api.Client, path string) (AppRole, error) {
+	return &appRoleEngine{vaultClient: vaultClient, path: path}, nil
+}
+
+func NewAppRoleWithPath(path string) (AppRole, error) {
+	vaultClient, err := api.NewClient(api.DefaultConfig())
+	if err != nil {
+		return nil, err
+	}
+	return &appRoleEngine{vaultClient: vaultClient, path: path}, nil
+}
@@ -0,0 +1,111 @@
+// +build integration
+
+package client_test
+
+import (
+	"github.com/hashicorp/vault/api"
+	. "github.com/jasoet/vault-client/pkg/client"
+	"github.com/stretchr/testify/assert"
+	"os"
+	"testing"
+)
+
+type appRoleTestCtx struct {
+	vaultClient *api.Client
+}
+
+func (ctx *appRoleTestCtx) setup(t *testing.T) {
+	config := &api.Config{Address: os.Getenv("TEST_VAULT_ADDR")}
+	client, err := api.NewClient(config)
+	assert.Nil(t, err)
+
+	client.SetToken(os.Getenv("TEST_VAULT_TOKEN"))
+
+	ctx.vaultClient = client
+}
+
+func TestAppRole(t *testing.T) {
+	ctx := new(appRoleTestCtx)
+	ctx.setup(t)
+
+	engine, err := NewAppRole(ctx.vaultClient, "approle-test")
+	assert.Nil(t, err)
+	assert.NotNil(t, engine)
+
+	_ = engine.Enable()
+
+	roleName := "app-a"
+	role := AppRoleRole{
+		AuthTokenConfig: AuthTokenConfig{
+			TokenTtl:      600,
+			TokenMaxTtl:   1200,
+			TokenPolicies: []string{"default", "app-a"},
+		},
+		SecretIdNumUses: 5,
+		SecretIdTtl:     3600,
+	}
+
+	t.Run("should create and read role", func(t *testing.T) {
+		err := engine.CreateRole(roleName, role)
+		assert.Nil(t, err)
+
+		result, err := engine.ReadRole(roleName)
+		assert.Nil(t, err)
+		assert.Equal(t, role.TokenTtl, result.TokenTtl)
+		assert.Equal(t, role.TokenPolicies, result.TokenPolicies)
+		assert.Equal(t, role.SecretIdNumUses, result.SecretIdNumUses)
+
+		list, err := engine.ListRole()
+		assert.Nil(t, err)
+		assert.Contains(t, list, roleName)
+
+		roleId, err := engine.ReadRoleId(roleName)
+		assert.Nil(t, err)
+		assert.NotEmpty(t, roleId)
+	})
+
+	var secretId *AppRoleSecretId
+	t.Run("should generate secret id with metadata and cidr", func(t *testing.T) {
+		secretId, err = engine.GenerateSecretId(roleName, AppRoleSecretIdOptions{
+			Metadata: map[string]string{"host": "app-a-1"},
+			CidrList: []string{"10.0.0.0/8"},
+		})
+		assert.Nil(t, err)
+		assert.NotEmpty(t, secretId.SecretId)
+		assert.NotEmpty(t, secretId.SecretIdAccessor)
+
+		accessors, err := engine.ListSecretIdAccessor(roleName)
+		assert.Nil(t, err)
+		assert.Contains(t, accessors, secretId.SecretIdAccessor)
+
+		info, err := engine.ReadSecretIdAccessor(roleName, secretId.SecretIdAccessor)
+		assert.Nil(t, err)
+		assert.Equal(t, map[string]string{"host": "app-a-1"}, info.Metadata)
+		assert.Equal(t, []string{"10.0.0.0/8"}, info.CidrList)
+		assert.NotNil(t, info.CreationTime)
+	})
+
+	t.Run("destroyed secret ids should not be listed", func(t *testing.T) {
+		err := engine.DestroySecretId(roleName, secretId.SecretId)
+		assert.Nil(t, err)
+
+		other, err := engine.GenerateSecretId(roleName, AppRoleSecretIdOptions{})
+		assert.Nil(t, err)
+		err = engine.DestroySecretIdAccessor(roleName, other.SecretIdAccessor)
+		assert.Nil(t, err)
+
+		accessors, err := engine.ListSecretIdAccessor(roleName)
+		assert.Nil(t, err)
+		assert.NotContains(t, accessors, secretId.SecretIdAccessor)
+		assert.NotContains(t, accessors, other.SecretIdAccessor)
+	})
+
+	t.Run("cannot fetch deleted role", func(t *testing.T) {
+		err := engine.DeleteRole(roleName)
+		assert.Nil(t, err)
+
+		result, err := engine.ReadRole(roleName)
+		assert.NotNil(t, err)
+		assert.Nil(t, result)
+	})
+}
@@ -0,0 +1,111 @@
+package client
+
+import (
+	"fmt"
+	"github.com/hashicorp/vault/api"
+	"github.com/jasoet/vault-client/pkg/util"
+)
+
+type jwtEngine struct {
+	vaultClient *api.Client
+	path        string
+}
+
+func (j jwtEngine) Path() string {
+	return j.path
+}
+
+func (j jwtEngine) Enable() (err error) {
+	data := map[string]interface{}{"type": "jwt"}
+	_, err = j.vaultClient.Logical().Write(fmt.Sprintf("/sys/auth/%v", j.path), data)
+	return
+}
+
+func (j jwtEngine) Configure(config JWTAuthConfig) (err error) {
+	payload, err := util.StructToMapE(config)
+	if err != nil {
+		return
+	}
+
+	_, err = j.vaultClient.Logical().Write(fmt.Sprintf("auth/%v/config", j.path), payload)
+	return
+}
+
+func (j jwtEngine) ReadConfig() (config *JWTAuthConfig, err error) {
+	result, err := j.vaultClient.Logical().Read(fmt.Sprintf("auth/%v/config", j.path))
+	if err != nil {
+		return
+	}
+
+	if result == nil {
+		err = fmt.Errorf("%v is not configured", j.path)
+		return
+	}
+
+	config = new(JWTAuthConfig)
+	err = util.MapToStruct(result.Data, config)
+	return
+}
+
+func (j jwtEngine) CreateRole(name string, role JWTRole) (err error) {
+	payload, err := util.StructToMapE(role)
+	if err != nil {
+		return
+	}
+
+	_, err = j.vaultClient.Logical().Write(fmt.Sprintf("auth/%v/role/%v", j.path, name), payload)
+	return
+}
+
+func (j jwtEngine) ReadRole(name string) (role *JWTRole, err error) {
+	result, err := j.vaultClient.Logical().Read(fmt.Sprintf("auth/%v/role/%v", j.path, name))
+	if err != nil {
+		return
+	}
+
+	if result == nil {
+		err = fmt.Errorf("%v is not found", name)
+		return
+	}
+
+	role = new(JWTRole)
+	err = util.MapToStruct(result.Data, role)
+	return
+}
+
+func (j jwtEngine) DeleteRole(name string) (err error) {
+	_, err = j.vaultClient.Logical().Delete(fmt.Sprintf("auth/%v/role/%v", j.path, name))
+	return
+}
+
+func (j jwtEngine) ListRole() (list []string, err error) {
+	result, err := j.vaultClient.Logical().List(fmt.Sprintf("auth/%v/role", j.path))
+	if err != nil || result == nil {
+		return []string{}, nil
+	}
+
+	if val, ok := result.Data["keys"]; ok {
+		list = util.ToArrStr(val.([]interface{}))
+	}
+	return
+}
+
+func DefaultJWT() (JWT, error) {
+	vaultClient, err := api.NewClient(api.DefaultConfig())
+	if err != nil {
+		return nil, err
+	}
+	return &jwtEngine{vaultClient: vaultClient, path: "jwt"}, nil
+}
+
+func NewJWT(vaultClient *api.Client, path string) (JWT, error) {
+	return &jwtEngine{vaultClient: vaultClient, path: path}, nil
+}
+
+func NewJWTWithPath(path string) (JWT, error) {
+	vaultClient, err := api.NewClient(api.DefaultConfig())
+	if err != nil {
+		return nil, err
+	}
+	return &jwtEngine{vaultClient: vaultClient, path: path}, nil
+}
@@ -0,0 +1,95 @@
+// +build integration
+
+package client_test
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"github.com/hashicorp/vault/api"
+	. "github.com/jasoet/vault-client/pkg/client"
+	"github.com/stretchr/testify/assert"
+	"os"
+	"testing"
+)
+
+type jwtTestCtx struct {
+	vaultClient *api.Client
+}
+
+func (ctx *jwtTestCtx) setup(t *testing.T) {
+	config := &api.Config{Address: os.Getenv("TEST_VAULT_ADDR")}
+	client, err := api.NewClient(config)
+	assert.Nil(t, err)
+
+	client.SetToken(os.Getenv("TEST_VAULT_TOKEN"))
+
+	ctx.vaultClient = client
+}
+
+func TestJWT(t *testing.T) {
+	ctx := new(jwtTestCtx)
+	ctx.setup(t)
+
+	engine, err := NewJWT(ctx.vaultClient, "jwt-test")
+	assert.Nil(t, err)
+	assert.NotNil(t, engine)
+
+	_ = engine.Enable()
+
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	assert.Nil(t, err)
+	der, err := x509.MarshalPKIXPublicKey(&key.PublicKey)
+	assert.Nil(t, err)
+	publicKey := string(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der}))
+
+	t.Run("should configure and read config", func(t *testing.T) {
+		err := engine.Configure(JWTAuthConfig{
+			JwtValidationPubkeys: []string{publicKey},
+			BoundIssuer:          "https://ci.example.com",
+		})
+		assert.Nil(t, err)
+
+		config, err := engine.ReadConfig()
+		assert.Nil(t, err)
+		assert.Len(t, config.JwtValidationPubkeys, 1)
+		assert.Equal(t, "https://ci.example.com", config.BoundIssuer)
+	})
+
+	roleName := "ci"
+	role := JWTRole{
+		AuthTokenConfig: AuthTokenConfig{TokenTtl: 600, TokenPolicies: []string{"ci"}},
+		RoleType:        JWTRoleJWT,
+		UserClaim:       "sub",
+		BoundAudiences:  []string{"vault"},
+		BoundClaims:     map[string]interface{}{"project": "vault-client"},
+		ClaimMappings:   map[string]string{"pipeline": "pipeline"},
+	}
+
+	t.Run("should create and read role", func(t *testing.T) {
+		err := engine.CreateRole(roleName, role)
+		assert.Nil(t, err)
+
+		result, err := engine.ReadRole(roleName)
+		assert.Nil(t, err)
+		assert.Equal(t, role.RoleType, result.RoleType)
+		assert.Equal(t, role.UserClaim, result.UserClaim)
+		assert.Equal(t, role.BoundAudiences, result.BoundAudiences)
+		assert.Equal(t, role.BoundClaims, result.BoundClaims)
+		assert.Equal(t, role.ClaimMappings, result.ClaimMappings)
+
+		list, err := engine.ListRole()
+		assert.Nil(t, err)
+		assert.Contains(t, list, roleName)
+	})
+
+	t.Run("cannot fetch deleted role", func(t *testing.T) {
+		err := engine.DeleteRole(roleName)
+		assert.Nil(t, err)
+
+		result, err := engine.ReadRole(roleName)
+		assert.NotNil(t, err)
+		assert.Nil(t, result)
+	})
+}
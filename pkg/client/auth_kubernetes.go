@@ -0,0 +1,111 @@
+package client
+
+import (
+	"fmt"
+	"github.com/hashicorp/vault/api"
+	"github.com/jasoet/vault-client/pkg/util"
+)
+
+type kubernetesEngine struct {
+	vaultClient *api.Client
+	path        string
+}
+
+func (k kubernetesEngine) Path() string {
+	return k.path
+}
+
+func (k kubernetesEngine) Enable() (err error) {
+	data := map[string]interface{}{"type": "kubernetes"}
+	_, err = k.vaultClient.Logical().Write(fmt.Sprintf("/sys/auth/%v", k.path), data)
+	return
+}
+
+func (k kubernetesEngine) Configure(config KubernetesAuthConfig) (err error) {
+	payload, err := util.StructToMapE(config)
+	if err != nil {
+		return
+	}
+
+	_, err = k.vaultClient.Logical().Write(fmt.Sprintf("auth/%v/config", k.path), payload)
+	return
+}
+
+func (k kubernetesEngine) ReadConfig() (config *KubernetesAuthConfig, err error) {
+	result, err := k.vaultClient.Logical().Read(fmt.Sprintf("auth/%v/config", k.path))
+	if err != nil {
+		return
+	}
+
+	if result == nil {
+		err = fmt.Errorf("%v is not configured", k.path)
+		return
+	}
+
+	config = new(KubernetesAuthConfig)
+	err = util.MapToStruct(result.Data, config)
+	return
+}
+
+func (k kubernetesEngine) CreateRole(name string, role KubernetesRole) (err error) {
+	payload, err := util.StructToMapE(role)
+	if err != nil {
+		return
+	}
+
+	_, err = k.vaultClient.Logical().Write(fmt.Sprintf("auth/%v/role/%v", k.path, name), payload)
+	return
+}
+
+func (k kubernetesEngine) ReadRole(name string) (role *KubernetesRole, err error) {
+	result, err := k.vaultClient.Logical().Read(fmt.Sprintf("auth/%v/role/%v", k.path, name))
+	if err != nil {
+		return
+	}
+
+	if result == nil {
+		err = fmt.Errorf("%v is not found", name)
+		return
+	}
+
+	role = new(KubernetesRole)
+	err = util.MapToStruct(result.Data, role)
+	return
+}
+
+func (k kubernetesEngine) DeleteRole(name string) (err error) {
+	_, err = k.vaultClient.Logical().Delete(fmt.Sprintf("auth/%v/role/%v", k.path, name))
+	return
+}
+
+func (k kubernetesEngine) ListRole() (list []string, err error) {
+	result, err := k.vaultClient.Logical().List(fmt.Sprintf("auth/%v/role", k.path))
+	if err != nil || result == nil {
+		return []string{}, nil
+	}
+
+	if val, ok := result.Data["keys"]; ok {
+		list = util.ToArrStr(val.([]interface{}))
+	}
+	return
+}
+
+func DefaultKubernetes() (Kubernetes, error) {
+	vaultClient, err := api.NewClient(api.DefaultConfig())
+	if err != nil {
+		return nil, err
+	}
+	return &kubernetesEngine{vaultClient: vaultClient, path: "kubernetes"}, nil
+}
+
+func NewKubernetes(vaultClient *api.Client, path string) (Kubernetes, error) {
+	return &kubernetesEngine{vaultClient: vaultClient, path: path}, nil
+}
+
+func NewKubernetesWithPath(path string) (Kubernetes, error) {
+	vaultClient, err := api.NewClient(api.DefaultConfig())
+	if err != nil {
+		return nil, err
+	}
+	return &kubernetesEngine{vaultClient: vaultClient, path: path}, nil
+}
@@ -0,0 +1,79 @@
+// +build integration
+
+package client_test
+
+import (
+	"github.com/hashicorp/vault/api"
+	. "github.com/jasoet/vault-client/pkg/client"
+	"github.com/stretchr/testify/assert"
+	"os"
+	"testing"
+)
+
+type kubernetesTestCtx struct {
+	vaultClient *api.Client
+}
+
+func (ctx *kubernetesTestCtx) setup(t *testing.T) {
+	config := &api.Config{Address: os.Getenv("TEST_VAULT_ADDR")}
+	client, err := api.NewClient(config)
+	assert.Nil(t, err)
+
+	client.SetToken(os.Getenv("TEST_VAULT_TOKEN"))
+
+	ctx.vaultClient = client
+}
+
+func TestKubernetes(t *testing.T) {
+	ctx := new(kubernetesTestCtx)
+	ctx.setup(t)
+
+	engine, err := NewKubernetes(ctx.vaultClient, "kubernetes-test")
+	assert.Nil(t, err)
+	assert.NotNil(t, engine)
+
+	_ = engine.Enable()
+
+	t.Run("should configure and read config", func(t *testing.T) {
+		err := engine.Configure(KubernetesAuthConfig{
+			KubernetesHost:    "https://kubernetes.default.svc:443",
+			DisableLocalCaJwt: true,
+		})
+		assert.Nil(t, err)
+
+		config, err := engine.ReadConfig()
+		assert.Nil(t, err)
+		assert.Equal(t, "https://kubernetes.default.svc:443", config.KubernetesHost)
+	})
+
+	roleName := "app-a"
+	role := KubernetesRole{
+		AuthTokenConfig:               AuthTokenConfig{TokenTtl: 600, TokenPolicies: []string{"app-a"}},
+		BoundServiceAccountNames:      []string{"app-a"},
+		BoundServiceAccountNamespaces: []string{"default", "staging"},
+	}
+
+	t.Run("should create and read role", func(t *testing.T) {
+		err := engine.CreateRole(roleName, role)
+		assert.Nil(t, err)
+
+		result, err := engine.ReadRole(roleName)
+		assert.Nil(t, err)
+		assert.Equal(t, role.BoundServiceAccountNames, result.BoundServiceAccountNames)
+		assert.Equal(t, role.BoundServiceAccountNamespaces, result.BoundServiceAccountNamespaces)
+		assert.Equal(t, role.TokenPolicies, result.TokenPolicies)
+
+		list, err := engine.ListRole()
+		assert.Nil(t, err)
+		assert.Contains(t, list, roleName)
+	})
+
+	t.Run("cannot fetch deleted role", func(t *testing.T) {
+		err := engine.DeleteRole(roleName)
+		assert.Nil(t, err)
+
+		result, err := engine.ReadRole(roleName)
+		assert.NotNil(t, err)
+		assert.Nil(t, result)
+	})
+}
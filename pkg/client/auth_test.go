@@ -0,0 +1,84 @@
+// +build integration
+
+package client_test
+
+import (
+	"github.com/hashicorp/vault/api"
+	. "github.com/jasoet/vault-client/pkg/client"
+	"github.com/stretchr/testify/assert"
+	"os"
+	"testing"
+)
+
+type authTestCtx struct {
+	vaultClient *api.Client
+}
+
+func (ctx *authTestCtx) setup(t *testing.T) {
+	config := &api.Config{Address: os.Getenv("TEST_VAULT_ADDR")}
+	client, err := api.NewClient(config)
+	assert.Nil(t, err)
+
+	client.SetToken(os.Getenv("TEST_VAULT_TOKEN"))
+
+	ctx.vaultClient = client
+}
+
+func TestAuth(t *testing.T) {
+	ctx := new(authTestCtx)
+	ctx.setup(t)
+
+	engine, err := NewAuth(ctx.vaultClient)
+	assert.Nil(t, err)
+	assert.NotNil(t, engine)
+
+	path := "auth-userpass"
+	_ = engine.DisableAuth(path)
+
+	t.Run("should enable auth method with config", func(t *testing.T) {
+		err := engine.EnableAuth(path, AuthMount{
+			Type:        "userpass",
+			Description: "userpass for auth test",
+			Config:      AuthMountConfig{DefaultLeaseTtl: 3600, MaxLeaseTtl: 7200},
+		})
+		assert.Nil(t, err)
+
+		mounts, err := engine.ListAuth()
+		assert.Nil(t, err)
+		assert.Contains(t, mounts, "token")
+
+		mount, ok := mounts[path]
+		assert.True(t, ok)
+		assert.Equal(t, "userpass", mount.Type)
+		assert.Equal(t, "userpass for auth test", mount.Description)
+		assert.NotEmpty(t, mount.Accessor)
+		assert.Equal(t, 3600, mount.Config.DefaultLeaseTtl)
+	})
+
+	t.Run("should tune auth method", func(t *testing.T) {
+		err := engine.TuneAuth(path, AuthMountConfig{
+			MaxLeaseTtl:       14400,
+			ListingVisibility: "unauth",
+		})
+		assert.Nil(t, err)
+
+		config, err := engine.ReadAuthTune(path)
+		assert.Nil(t, err)
+		assert.Equal(t, 3600, config.DefaultLeaseTtl)
+		assert.Equal(t, 14400, config.MaxLeaseTtl)
+		assert.Equal(t, "unauth", config.ListingVisibility)
+	})
+
+	t.Run("disabled auth method should not be listed", func(t *testing.T) {
+		err := engine.DisableAuth(path)
+		assert.Nil(t, err)
+
+		mounts, err := engine.ListAuth()
+		assert.Nil(t, err)
+		assert.NotContains(t, mounts, path)
+
+		config, err := engine.ReadAuthTune(path)
+		assert.NotNil(t, err)
+		assert.Nil(t, config)
+	})
+}
@@ -0,0 +1,48 @@
+package client
+
+import (
+	"crypto/rsa"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+)
+
+// TLSCertificate return client certificate and its private key, only valid for ClientCertificateCredential
+func (c Creds) TLSCertificate() (certificate tls.Certificate, err error) {
+	if c.CredentialType != ClientCertificateCredential {
+		err = fmt.Errorf("%v creds does not contain client certificate", c.CredentialType)
+		return
+	}
+
+	return tls.X509KeyPair([]byte(c.ClientCertificate), []byte(c.PrivateKey))
+}
+
+// RSAPrivateKey parse PEM encoded private key, in PKCS#8 or PKCS#1 format, only valid for RSAPrivateKeyCredential
+func (c Creds) RSAPrivateKey() (key *rsa.PrivateKey, err error) {
+	if c.CredentialType != RSAPrivateKeyCredential {
+		err = fmt.Errorf("%v creds does not contain rsa private key", c.CredentialType)
+		return
+	}
+
+	block, _ := pem.Decode([]byte(c.RsaPrivateKey))
+	if block == nil {
+		err = fmt.Errorf("rsa private key is not PEM encoded")
+		return
+	}
+
+	if key, err = x509.ParsePKCS1PrivateKey(block.Bytes); err == nil {
+		return
+	}
+
+	parsed, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return
+	}
+
+	key, ok := parsed.(*rsa.PrivateKey)
+	if !ok {
+		err = fmt.Errorf("private key is not rsa key")
+	}
+	return
+}
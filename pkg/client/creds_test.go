@@ -0,0 +1,65 @@
+package client_test
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	. "github.com/jasoet/vault-client/pkg/client"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"math/big"
+	"testing"
+	"time"
+)
+
+func TestCreds(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	pkcs8, err := x509.MarshalPKCS8PrivateKey(key)
+	require.NoError(t, err)
+	keyPem := string(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: pkcs8}))
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "v-role-user"},
+		NotBefore:    time.Now(),
+		NotAfter:     time.Now().Add(time.Hour),
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+	certPem := string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}))
+
+	t.Run("should parse pkcs8 and pkcs1 rsa private key", func(t *testing.T) {
+		creds := Creds{CredentialType: RSAPrivateKeyCredential, Username: "v-role-user", RsaPrivateKey: keyPem}
+		parsed, err := creds.RSAPrivateKey()
+		require.NoError(t, err)
+		assert.True(t, key.Equal(parsed))
+
+		creds.RsaPrivateKey = string(pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)}))
+		parsed, err = creds.RSAPrivateKey()
+		require.NoError(t, err)
+		assert.True(t, key.Equal(parsed))
+	})
+
+	t.Run("should return tls certificate from client certificate creds", func(t *testing.T) {
+		creds := Creds{CredentialType: ClientCertificateCredential, ClientCertificate: certPem, PrivateKey: keyPem, PrivateKeyType: "rsa"}
+		certificate, err := creds.TLSCertificate()
+		require.NoError(t, err)
+		require.Len(t, certificate.Certificate, 1)
+		assert.Equal(t, der, certificate.Certificate[0])
+	})
+
+	t.Run("should return err on mismatched credential type", func(t *testing.T) {
+		creds := Creds{CredentialType: PasswordCredential, Username: "user", Password: "password"}
+		_, err := creds.TLSCertificate()
+		assert.NotNil(t, err)
+
+		parsed, err := creds.RSAPrivateKey()
+		assert.NotNil(t, err)
+		assert.Nil(t, parsed)
+	})
+}
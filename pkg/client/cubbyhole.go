@@ -0,0 +1,73 @@
+package client
+
+import (
+	"fmt"
+	"github.com/hashicorp/vault/api"
+	"github.com/jasoet/vault-client/pkg/util"
+)
+
+// cubbyholeEngine store secrets scoped to the client token, they are gone when the token expires or revoked
+type cubbyholeEngine struct {
+	vaultClient *api.Client
+}
+
+func (c cubbyholeEngine) Path() string {
+	return "cubbyhole"
+}
+
+func (c cubbyholeEngine) Write(path string, input interface{}) (err error) {
+	payload, err := util.StructToMapE(input)
+	if err != nil {
+		return
+	}
+
+	_, err = c.vaultClient.Logical().Write(fmt.Sprintf("cubbyhole/%v", path), payload)
+	return
+}
+
+func (c cubbyholeEngine) Read(path string, output interface{}) (err error) {
+	result, err := c.vaultClient.Logical().Read(fmt.Sprintf("cubbyhole/%v", path))
+	if err != nil {
+		return
+	}
+
+	if result == nil {
+		err = fmt.Errorf("%v is not found", path)
+		return
+	}
+
+	err = util.MapToStruct(result.Data, output)
+	return
+}
+
+func (c cubbyholeEngine) List(path string) (list []string, err error) {
+	result, err := c.vaultClient.Logical().List(fmt.Sprintf("cubbyhole/%v", path))
+	if err != nil || result == nil {
+		return []string{}, nil
+	}
+
+	if val, ok := result.Data["keys"]; ok {
+		list = util.ToArrStr(val.([]interface{}))
+	}
+	return
+}
+
+func (c cubbyholeEngine) Delete(path string) (err error) {
+	_, err = c.vaultClient.Logical().Delete(fmt.Sprintf("cubbyhole/%v", path))
+	return
+}
+
+func DefaultCubbyhole() (cubbyhole Cubbyhole, err error) {
+	vaultClient, err := api.NewClient(api.DefaultConfig())
+	if err != nil {
+		return
+	}
+
+	cubbyhole = &cubbyholeEngine{vaultClient: vaultClient}
+	return
+}
+
+func NewCubbyhole(vaultClient *api.Client) (cubbyhole Cubbyhole, err error) {
+	cubbyhole = &cubbyholeEngine{vaultClient: vaultClient}
+	return
+}
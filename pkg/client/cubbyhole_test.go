@@ -0,0 +1,83 @@
+// +build integration
+
+package client_test
+
+import (
+	"github.com/hashicorp/vault/api"
+	. "github.com/jasoet/vault-client/pkg/client"
+	"github.com/stretchr/testify/assert"
+	"os"
+	"testing"
+)
+
+type cubbyholeTestCtx struct {
+	vaultClient *api.Client
+}
+
+func (ctx *cubbyholeTestCtx) setup(t *testing.T) {
+	config := &api.Config{Address: os.Getenv("TEST_VAULT_ADDR")}
+	client, err := api.NewClient(config)
+	assert.Nil(t, err)
+
+	client.SetToken(os.Getenv("TEST_VAULT_TOKEN"))
+
+	ctx.vaultClient = client
+}
+
+func TestCubbyhole(t *testing.T) {
+	ctx := new(cubbyholeTestCtx)
+	ctx.setup(t)
+
+	engine, err := NewCubbyhole(ctx.vaultClient)
+	assert.Nil(t, err)
+	assert.NotNil(t, engine)
+
+	dataPath := "bootstrap/creds"
+	sampleData := Creds{
+		Username: "bootstrap",
+		Password: "bootstrap-password",
+	}
+
+	t.Run("write secret data should success", func(t *testing.T) {
+		err := engine.Write(dataPath, sampleData)
+		assert.Nil(t, err)
+	})
+
+	t.Run("read secret data should produce correct result", func(t *testing.T) {
+		output := new(Creds)
+		err := engine.Read(dataPath, output)
+		assert.Nil(t, err)
+		assert.Equal(t, sampleData.Username, output.Username)
+		assert.Equal(t, sampleData.Password, output.Password)
+	})
+
+	t.Run("list should return written path", func(t *testing.T) {
+		list, err := engine.List("bootstrap")
+		assert.Nil(t, err)
+		assert.Contains(t, list, "creds")
+	})
+
+	t.Run("secret data should not be visible to other token", func(t *testing.T) {
+		secret, err := ctx.vaultClient.Auth().Token().Create(&api.TokenCreateRequest{Policies: []string{"default"}})
+		assert.Nil(t, err)
+
+		otherClient, err := ctx.vaultClient.Clone()
+		assert.Nil(t, err)
+		otherClient.SetToken(secret.Auth.ClientToken)
+
+		otherEngine, err := NewCubbyhole(otherClient)
+		assert.Nil(t, err)
+
+		err = otherEngine.Read(dataPath, new(Creds))
+		assert.NotNil(t, err)
+	})
+
+	t.Run("cannot read deleted secret data", func(t *testing.T) {
+		err := engine.Delete(dataPath)
+		assert.Nil(t, err)
+
+		err = engine.Read(dataPath, new(Creds))
+		assert.NotNil(t, err)
+	})
+
+}
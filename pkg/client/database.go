@@ -11,9 +11,13 @@ type databaseEngine struct {
 	path        string
 }
 
-type usernamePassword struct {
-	Username string `json:"username"`
-	Password string `json:"password"`
+type credsData struct {
+	Username          string `json:"username"`
+	Password          string `json:"password"`
+	RsaPrivateKey     string `json:"rsa_private_key"`
+	ClientCertificate string `json:"client_certificate"`
+	PrivateKey        string `json:"private_key"`
+	PrivateKeyType    string `json:"private_key_type"`
 }
 
 func (d databaseEngine) GenerateCreds(roleName string) (creds *Creds, err error) {
@@ -27,18 +31,68 @@ func (d databaseEngine) GenerateCreds(roleName string) (creds *Creds, err error)
 		return
 	}
 
-	userPass := new(usernamePassword)
-	err = util.MapToStruct(result.Data, userPass)
+	return toCreds(result)
+}
+
+func (d databaseEngine) GenerateCredsWrapped(roleName string, options WrapOptions) (info *WrapInfo, err error) {
+	vaultClient, err := wrappedClient(d.vaultClient, options)
+	if err != nil {
+		return
+	}
+
+	result, err := vaultClient.Logical().Read(fmt.Sprintf("%v/creds/%v", d.path, roleName))
+	if err != nil {
+		return
+	}
+
+	if result == nil {
+		err = fmt.Errorf("%v is not found", roleName)
+		return
+	}
+
+	return toWrapInfo(result)
+}
+
+func (d databaseEngine) UnwrapCreds(token string) (creds *Creds, err error) {
+	result, err := d.vaultClient.Logical().Unwrap(token)
+	if err != nil {
+		return
+	}
+
+	if result == nil {
+		err = fmt.Errorf("wrapped creds is not found")
+		return
+	}
+
+	return toCreds(result)
+}
+
+func toCreds(secret *api.Secret) (creds *Creds, err error) {
+	data := new(credsData)
+	err = util.MapToStruct(secret.Data, data)
 	if err != nil {
 		return
 	}
 
 	creds = new(Creds)
-	creds.LeaseId = result.LeaseID
-	creds.LeaseDuration = result.LeaseDuration
-	creds.Renewable = result.Renewable
-	creds.Username = userPass.Username
-	creds.Password = userPass.Password
+	creds.LeaseId = secret.LeaseID
+	creds.LeaseDuration = secret.LeaseDuration
+	creds.Renewable = secret.Renewable
+	creds.Username = data.Username
+	creds.Password = data.Password
+	creds.RsaPrivateKey = data.RsaPrivateKey
+	creds.ClientCertificate = data.ClientCertificate
+	creds.PrivateKey = data.PrivateKey
+	creds.PrivateKeyType = data.PrivateKeyType
+
+	switch {
+	case data.RsaPrivateKey != "":
+		creds.CredentialType = RSAPrivateKeyCredential
+	case data.ClientCertificate != "":
+		creds.CredentialType = ClientCertificateCredential
+	default:
+		creds.CredentialType = PasswordCredential
+	}
 
 	return
 }
@@ -68,7 +122,22 @@ func (d databaseEngine) ListRole() (list []string, err error) {
 }
 
 func (d databaseEngine) CreateConnection(name string, config DatabaseConfig) (err error) {
-	_, err = d.vaultClient.Logical().Write(fmt.Sprintf("%v/config/%v", d.path, name), util.StructToMap(config))
+	payload, err := util.StructToMapE(config)
+	if err != nil {
+		return
+	}
+
+	if config.Options != nil {
+		options, err := util.StructToMapE(config.Options)
+		if err != nil {
+			return err
+		}
+		for key, value := range options {
+			payload[key] = value
+		}
+	}
+
+	_, err = d.vaultClient.Logical().Write(fmt.Sprintf("%v/config/%v", d.path, name), payload)
 	return
 }
 
@@ -89,10 +158,12 @@ type configConnectionDetail struct {
 
 type databaseConfigDetail struct {
 	Type                   DatabaseType           `json:"plugin_name"`
-	ConnectionDetails      configConnectionDetail `json:"connection_details"`
+	ConnectionDetails      map[string]interface{} `json:"connection_details"`
 	AllowedRoles           []string               `json:"allowed_roles"`
 	RootRotationStatements []string               `json:"root_credentials_rotate_statements"`
 	PasswordPolicy         string                 `json:"password_policy"`
+	VerifyConnection       *bool                  `json:"verify_connection"`
+	PluginVersion          string                 `json:"plugin_version"`
 }
 
 func (d databaseEngine) ReadConnection(name string) (config *DatabaseConfig, err error) {
@@ -112,18 +183,40 @@ func (d databaseEngine) ReadConnection(name string) (config *DatabaseConfig, err
 		return
 	}
 
+	connectionDetail := new(configConnectionDetail)
+	err = util.MapToStruct(configDetail.ConnectionDetails, connectionDetail)
+	if err != nil {
+		return
+	}
+
+	options := NewDatabasePluginOptions(configDetail.Type)
+	if options != nil {
+		err = util.MapToStruct(configDetail.ConnectionDetails, options)
+		if err != nil {
+			return
+		}
+	}
+
 	config = new(DatabaseConfig)
 	config.Type = configDetail.Type
-	config.Username = configDetail.ConnectionDetails.Username
-	config.ConnectionUrl = configDetail.ConnectionDetails.ConnectionUrl
+	config.Username = connectionDetail.Username
+	config.ConnectionUrl = connectionDetail.ConnectionUrl
 	config.AllowedRoles = configDetail.AllowedRoles
 	config.PasswordPolicy = configDetail.PasswordPolicy
 	config.RootRotationStatements = configDetail.RootRotationStatements
+	config.VerifyConnection = configDetail.VerifyConnection
+	config.PluginVersion = configDetail.PluginVersion
+	config.Options = options
 	return
 }
 
 func (d databaseEngine) CreateRole(name string, config DatabaseRole) (err error) {
-	_, err = d.vaultClient.Logical().Write(fmt.Sprintf("%v/roles/%v", d.path, name), util.StructToMap(config))
+	payload, err := util.StructToMapE(config)
+	if err != nil {
+		return
+	}
+
+	_, err = d.vaultClient.Logical().Write(fmt.Sprintf("%v/roles/%v", d.path, name), payload)
 	return
 }
 
@@ -148,6 +241,76 @@ func (d databaseEngine) ReadRole(name string) (role *DatabaseRole, err error) {
 	return
 }
 
+func (d databaseEngine) CreateStaticRole(name string, role DatabaseStaticRole) (err error) {
+	payload, err := util.StructToMapE(role)
+	if err != nil {
+		return
+	}
+
+	_, err = d.vaultClient.Logical().Write(fmt.Sprintf("%v/static-roles/%v", d.path, name), payload)
+	return
+}
+
+func (d databaseEngine) ReadStaticRole(name string) (role *DatabaseStaticRole, err error) {
+	result, err := d.vaultClient.Logical().Read(fmt.Sprintf("%v/static-roles/%v", d.path, name))
+	if err != nil {
+		return
+	}
+
+	if result == nil {
+		err = fmt.Errorf("%v is not found", name)
+		return
+	}
+
+	role = new(DatabaseStaticRole)
+	err = util.MapToStruct(result.Data, role)
+	return
+}
+
+func (d databaseEngine) DeleteStaticRole(name string) (err error) {
+	_, err = d.vaultClient.Logical().Delete(fmt.Sprintf("%v/static-roles/%v", d.path, name))
+	return
+}
+
+func (d databaseEngine) ListStaticRole() (list []string, err error) {
+	result, err := d.vaultClient.Logical().List(fmt.Sprintf("%v/static-roles", d.path))
+	if err != nil || result == nil {
+		return []string{}, nil
+	}
+
+	if val, ok := result.Data["keys"]; ok {
+		list = util.ToArrStr(val.([]interface{}))
+	}
+	return
+}
+
+func (d databaseEngine) ReadStaticCreds(roleName string) (creds *StaticCreds, err error) {
+	result, err := d.vaultClient.Logical().Read(fmt.Sprintf("%v/static-creds/%v", d.path, roleName))
+	if err != nil {
+		return
+	}
+
+	if result == nil {
+		err = fmt.Errorf("%v is not found", roleName)
+		return
+	}
+
+	creds = new(StaticCreds)
+	err = util.MapToStruct(result.Data, creds)
+	return
+}
+
+func (d databaseEngine) RotateStaticRole(name string) (err error) {
+	_, err = d.vaultClient.Logical().Write(fmt.Sprintf("%v/rotate-role/%v", d.path, name), map[string]interface{}{})
+	return
+}
+
+// RotateRoot rotate password of the connection's root user, after rotation the password is only known by Vault
+func (d databaseEngine) RotateRoot(connectionName string) (err error) {
+	_, err = d.vaultClient.Logical().Write(fmt.Sprintf("%v/rotate-root/%v", d.path, connectionName), map[string]interface{}{})
+	return
+}
+
 func (d databaseEngine) Path() string {
 	return d.path
 }
@@ -0,0 +1,158 @@
+package client
+
+// DatabasePluginOptions hold plugin specific connection fields, set it as DatabaseConfig.Options.
+// The fields are sent along with the common DatabaseConfig fields and decoded back from `connection_details`
+// by ReadConnection based on DatabaseConfig.Type.
+type DatabasePluginOptions interface {
+	databasePluginOptions()
+}
+
+// SQLOptions are shared by the SQL based plugins: PostgreSQL, Redshift, HANA and Snowflake use it as is,
+// MySQL, MSSQL and Oracle embed it in their own options.
+type SQLOptions struct {
+	MaxOpenConnections    int    `json:"max_open_connections,omitempty"`
+	MaxIdleConnections    int    `json:"max_idle_connections,omitempty"`
+	MaxConnectionLifetime string `json:"max_connection_lifetime,omitempty"` //use go duration format https://golang.org/pkg/time/#ParseDuration
+	UsernameTemplate      string `json:"username_template,omitempty"`
+	DisableEscaping       bool   `json:"disable_escaping,omitempty"`
+}
+
+type MySQLOptions struct {
+	SQLOptions
+	TlsCa             string `json:"tls_ca,omitempty"`
+	TlsCertificateKey string `json:"tls_certificate_key,omitempty"`
+	TlsServerName     string `json:"tls_server_name,omitempty"`
+	TlsSkipVerify     bool   `json:"tls_skip_verify,omitempty"`
+}
+
+type MSSQLOptions struct {
+	SQLOptions
+	ContainedDb bool `json:"contained_db,omitempty"`
+}
+
+type OracleOptions struct {
+	SQLOptions
+	SplitStatements    *bool `json:"split_statements,omitempty"`
+	DisconnectSessions *bool `json:"disconnect_sessions,omitempty"`
+}
+
+type MongoDBOptions struct {
+	WriteConcern      string `json:"write_concern,omitempty"`
+	TlsCa             string `json:"tls_ca,omitempty"`
+	TlsCertificateKey string `json:"tls_certificate_key,omitempty"`
+	UsernameTemplate  string `json:"username_template,omitempty"`
+}
+
+type MongoDBAtlasOptions struct {
+	PublicKey        string `json:"public_key,omitempty"`
+	PrivateKey       string `json:"private_key,omitempty"`
+	ProjectId        string `json:"project_id,omitempty"`
+	UsernameTemplate string `json:"username_template,omitempty"`
+}
+
+type RedisOptions struct {
+	Host        string `json:"host,omitempty"`
+	Port        int    `json:"port,omitempty"`
+	Tls         bool   `json:"tls,omitempty"`
+	InsecureTls bool   `json:"insecure_tls,omitempty"`
+	CaCert      string `json:"ca_cert,omitempty"`
+}
+
+type RedisElastiCacheOptions struct {
+	Url    string `json:"url,omitempty"`
+	Region string `json:"region,omitempty"`
+}
+
+type CassandraOptions struct {
+	Hosts            string `json:"hosts,omitempty"` // comma separated
+	Port             int    `json:"port,omitempty"`
+	ProtocolVersion  int    `json:"protocol_version,omitempty"`
+	Tls              bool   `json:"tls,omitempty"`
+	InsecureTls      bool   `json:"insecure_tls,omitempty"`
+	TlsServerName    string `json:"tls_server_name,omitempty"`
+	PemBundle        string `json:"pem_bundle,omitempty"`
+	PemJson          string `json:"pem_json,omitempty"`
+	SkipVerification bool   `json:"skip_verification,omitempty"`
+	ConnectTimeout   string `json:"connect_timeout,omitempty"`
+	LocalDatacenter  string `json:"local_datacenter,omitempty"`
+	SocketKeepAlive  string `json:"socket_keep_alive,omitempty"`
+	Consistency      string `json:"consistency,omitempty"`
+	UsernameTemplate string `json:"username_template,omitempty"`
+}
+
+type ElasticsearchOptions struct {
+	Url              string `json:"url,omitempty"`
+	CaCert           string `json:"ca_cert,omitempty"`
+	CaPath           string `json:"ca_path,omitempty"`
+	ClientCert       string `json:"client_cert,omitempty"`
+	ClientKey        string `json:"client_key,omitempty"`
+	TlsServerName    string `json:"tls_server_name,omitempty"`
+	Insecure         bool   `json:"insecure,omitempty"`
+	UsernameTemplate string `json:"username_template,omitempty"`
+	UseOldXpack      bool   `json:"use_old_xpack,omitempty"`
+}
+
+type CouchbaseOptions struct {
+	Hosts            string `json:"hosts,omitempty"` // comma separated
+	Tls              bool   `json:"tls,omitempty"`
+	InsecureTls      bool   `json:"insecure_tls,omitempty"`
+	Base64Pem        string `json:"base64pem,omitempty"`
+	BucketName       string `json:"bucket_name,omitempty"`
+	UsernameTemplate string `json:"username_template,omitempty"`
+}
+
+type InfluxDBOptions struct {
+	Host             string `json:"host,omitempty"`
+	Port             int    `json:"port,omitempty"`
+	Tls              bool   `json:"tls,omitempty"`
+	InsecureTls      bool   `json:"insecure_tls,omitempty"`
+	PemBundle        string `json:"pem_bundle,omitempty"`
+	PemJson          string `json:"pem_json,omitempty"`
+	ConnectTimeout   string `json:"connect_timeout,omitempty"`
+	UsernameTemplate string `json:"username_template,omitempty"`
+}
+
+func (SQLOptions) databasePluginOptions()              {}
+func (MySQLOptions) databasePluginOptions()            {}
+func (MSSQLOptions) databasePluginOptions()            {}
+func (OracleOptions) databasePluginOptions()           {}
+func (MongoDBOptions) databasePluginOptions()          {}
+func (MongoDBAtlasOptions) databasePluginOptions()     {}
+func (RedisOptions) databasePluginOptions()            {}
+func (RedisElastiCacheOptions) databasePluginOptions() {}
+func (CassandraOptions) databasePluginOptions()        {}
+func (ElasticsearchOptions) databasePluginOptions()    {}
+func (CouchbaseOptions) databasePluginOptions()        {}
+func (InfluxDBOptions) databasePluginOptions()         {}
+
+// NewDatabasePluginOptions return empty options matching the plugin type, nil for unknown (e.g. custom) plugins
+func NewDatabasePluginOptions(databaseType DatabaseType) DatabasePluginOptions {
+	switch databaseType {
+	case PostgreSQL, Redshift, HANA, Snowflake:
+		return new(SQLOptions)
+	case MySQL, MySQLAurora, MySQLRDS, MySQLLegacy:
+		return new(MySQLOptions)
+	case MSSQL:
+		return new(MSSQLOptions)
+	case Oracle:
+		return new(OracleOptions)
+	case MongoDB:
+		return new(MongoDBOptions)
+	case MongoDBAtlas:
+		return new(MongoDBAtlasOptions)
+	case Redis:
+		return new(RedisOptions)
+	case RedisElastiCache:
+		return new(RedisElastiCacheOptions)
+	case Cassandra:
+		return new(CassandraOptions)
+	case Elasticsearch:
+		return new(ElasticsearchOptions)
+	case Couchbase:
+		return new(CouchbaseOptions)
+	case InfluxDB:
+		return new(InfluxDBOptions)
+	default:
+		return nil
+	}
+}
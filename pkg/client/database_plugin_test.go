@@ -0,0 +1,62 @@
+package client_test
+
+import (
+	. "github.com/jasoet/vault-client/pkg/client"
+	"github.com/jasoet/vault-client/pkg/util"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestNewDatabasePluginOptions(t *testing.T) {
+	t.Run("should return options matching the plugin", func(t *testing.T) {
+		assert.IsType(t, &MySQLOptions{}, NewDatabasePluginOptions(MySQL))
+		assert.IsType(t, &MySQLOptions{}, NewDatabasePluginOptions(MySQLAurora))
+		assert.IsType(t, &SQLOptions{}, NewDatabasePluginOptions(PostgreSQL))
+		assert.IsType(t, &SQLOptions{}, NewDatabasePluginOptions(Snowflake))
+		assert.IsType(t, &MSSQLOptions{}, NewDatabasePluginOptions(MSSQL))
+		assert.IsType(t, &OracleOptions{}, NewDatabasePluginOptions(Oracle))
+		assert.IsType(t, &MongoDBOptions{}, NewDatabasePluginOptions(MongoDB))
+		assert.IsType(t, &RedisOptions{}, NewDatabasePluginOptions(Redis))
+		assert.IsType(t, &CassandraOptions{}, NewDatabasePluginOptions(Cassandra))
+		assert.IsType(t, &ElasticsearchOptions{}, NewDatabasePluginOptions(Elasticsearch))
+	})
+
+	t.Run("should return nil for unknown plugin", func(t *testing.T) {
+		assert.Nil(t, NewDatabasePluginOptions("custom-database-plugin"))
+	})
+}
+
+func TestDatabasePluginOptions_Mapping(t *testing.T) {
+	options := MySQLOptions{
+		SQLOptions: SQLOptions{
+			MaxOpenConnections:    5,
+			MaxConnectionLifetime: "30s",
+		},
+		TlsCa: "ca",
+	}
+
+	t.Run("should flatten embedded sql options and skip empty fields", func(t *testing.T) {
+		mapped, err := util.StructToMapE(options)
+		assert.NoError(t, err)
+		assert.Equal(t, map[string]interface{}{
+			"max_open_connections":    5,
+			"max_connection_lifetime": "30s",
+			"tls_ca":                  "ca",
+		}, mapped)
+	})
+
+	t.Run("should decode connection details back", func(t *testing.T) {
+		connectionDetails := map[string]interface{}{
+			"connection_url":          "{{username}}:{{password}}@tcp(db:3306)/",
+			"username":                "root",
+			"max_open_connections":    5,
+			"max_connection_lifetime": "30s",
+			"tls_ca":                  "ca",
+		}
+
+		decoded := NewDatabasePluginOptions(MySQL)
+		err := util.MapToStruct(connectionDetails, decoded)
+		assert.Nil(t, err)
+		assert.Equal(t, &options, decoded)
+	})
+}
@@ -0,0 +1,364 @@
+package client
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+const (
+	IssueError   = "error"
+	IssueWarning = "warning"
+)
+
+// Grant describe privileges given to generated users.
+// For MySQL empty Database means every database, for PostgreSQL Database only adds CONNECT privilege
+// and empty Schema means `public`. Empty Tables means all tables.
+type Grant struct {
+	Database   string   `json:"database"`
+	Schema     string   `json:"schema"`
+	Tables     []string `json:"tables"`
+	Privileges []string `json:"privileges"`
+}
+
+// StatementIssue is a problem found in role statements, Index point to the statement inside Field
+type StatementIssue struct {
+	Field    string `json:"field"`
+	Index    int    `json:"index"`
+	Severity string `json:"severity"`
+	Message  string `json:"message"`
+}
+
+func (i StatementIssue) String() string {
+	return fmt.Sprintf("%v %v[%v]: %v", i.Severity, i.Field, i.Index, i.Message)
+}
+
+// RoleBuilder generate DatabaseRole statements from declarative grants
+type RoleBuilder struct {
+	databaseType   DatabaseType
+	connectionName string
+	host           string
+	defaultTtl     int
+	maxTtl         int
+	grants         []Grant
+}
+
+func NewMySQLRole(connectionName string, grants ...Grant) *RoleBuilder {
+	return &RoleBuilder{databaseType: MySQL, connectionName: connectionName, host: "%", grants: grants}
+}
+
+func NewPostgreSQLRole(connectionName string, grants ...Grant) *RoleBuilder {
+	return &RoleBuilder{databaseType: PostgreSQL, connectionName: connectionName, grants: grants}
+}
+
+// WithHost set MySQL host part of generated users, default is `%`.
+// Host may contain letters, digits, `.`, `-`, `:`, `/` and the `%` and `_` wildcards, Build reject other hosts
+func (b *RoleBuilder) WithHost(host string) *RoleBuilder {
+	b.host = host
+	return b
+}
+
+func (b *RoleBuilder) WithTtl(defaultTtl int, maxTtl int) *RoleBuilder {
+	b.defaultTtl = defaultTtl
+	b.maxTtl = maxTtl
+	return b
+}
+
+func (b *RoleBuilder) WithGrant(grant Grant) *RoleBuilder {
+	b.grants = append(b.grants, grant)
+	return b
+}
+
+// Build return DatabaseRole with generated statements, error when grants are invalid or statements fail validation
+func (b *RoleBuilder) Build() (role DatabaseRole, err error) {
+	if len(b.grants) == 0 {
+		err = fmt.Errorf("role requires at least one grant")
+		return
+	}
+
+	for _, grant := range b.grants {
+		err = validateGrant(grant)
+		if err != nil {
+			return
+		}
+	}
+
+	if b.databaseType == MySQL && !hostPattern.MatchString(b.host) {
+		err = fmt.Errorf("invalid host %q", b.host)
+		return
+	}
+
+	role = DatabaseRole{
+		ConnectionName: b.connectionName,
+		DefaultTtl:     b.defaultTtl,
+		MaxTtl:         b.maxTtl,
+	}
+
+	switch b.databaseType {
+	case MySQL:
+		b.buildMySQL(&role)
+	case PostgreSQL:
+		b.buildPostgreSQL(&role)
+	default:
+		err = fmt.Errorf("role builder does not support %v", b.databaseType)
+		return
+	}
+
+	for _, issue := range ValidateRoleStatements(b.databaseType, role) {
+		if issue.Severity == IssueError {
+			err = fmt.Errorf("generated statements are invalid, %v", issue)
+			return
+		}
+	}
+
+	return
+}
+
+func (b *RoleBuilder) buildMySQL(role *DatabaseRole) {
+	user := fmt.Sprintf("'{{name}}'@'%v'", b.host)
+
+	role.CreationStatements = []string{fmt.Sprintf("CREATE USER %v IDENTIFIED BY '{{password}}';", user)}
+	for _, grant := range b.grants {
+		for _, object := range mysqlObjects(grant) {
+			role.CreationStatements = append(role.CreationStatements,
+				fmt.Sprintf("GRANT %v ON %v TO %v;", strings.Join(grant.Privileges, ", "), object, user))
+		}
+	}
+
+	role.RevocationStatements = []string{
+		fmt.Sprintf("REVOKE ALL PRIVILEGES, GRANT OPTION FROM %v;", user),
+		fmt.Sprintf("DROP USER %v;", user),
+	}
+	role.RollbackStatements = []string{fmt.Sprintf("DROP USER %v;", user)}
+}
+
+func (b *RoleBuilder) buildPostgreSQL(role *DatabaseRole) {
+	user := `"{{name}}"`
+
+	role.CreationStatements = []string{fmt.Sprintf("CREATE ROLE %v WITH LOGIN PASSWORD '{{password}}' VALID UNTIL '{{expiration}}';", user)}
+	var revocations []string
+	for _, grant := range b.grants {
+		schema := quotePostgreSQL(postgreSQLSchema(grant))
+		if grant.Database != "" {
+			database := quotePostgreSQL(grant.Database)
+			role.CreationStatements = append(role.CreationStatements, fmt.Sprintf("GRANT CONNECT ON DATABASE %v TO %v;", database, user))
+			revocations = append(revocations, fmt.Sprintf("REVOKE CONNECT ON DATABASE %v FROM %v;", database, user))
+		}
+
+		role.CreationStatements = append(role.CreationStatements, fmt.Sprintf("GRANT USAGE ON SCHEMA %v TO %v;", schema, user))
+		revocations = append(revocations, fmt.Sprintf("REVOKE USAGE ON SCHEMA %v FROM %v;", schema, user))
+
+		for _, object := range postgreSQLObjects(grant) {
+			role.CreationStatements = append(role.CreationStatements,
+				fmt.Sprintf("GRANT %v ON %v TO %v;", strings.Join(grant.Privileges, ", "), object, user))
+			revocations = append(revocations, fmt.Sprintf("REVOKE ALL PRIVILEGES ON %v FROM %v;", object, user))
+		}
+	}
+
+	// privileges on objects are revoked before schema usage and database connect
+	for i := len(revocations) - 1; i >= 0; i-- {
+		role.RevocationStatements = append(role.RevocationStatements, revocations[i])
+	}
+	role.RevocationStatements = append(role.RevocationStatements, fmt.Sprintf("DROP ROLE IF EXISTS %v;", user))
+
+	role.RenewStatements = []string{fmt.Sprintf("ALTER ROLE %v VALID UNTIL '{{expiration}}';", user)}
+	role.RollbackStatements = role.RevocationStatements
+}
+
+func mysqlObjects(grant Grant) []string {
+	database := "*"
+	if grant.Database != "" {
+		database = quoteMySQL(grant.Database)
+	}
+
+	if len(grant.Tables) == 0 {
+		return []string{fmt.Sprintf("%v.*", database)}
+	}
+
+	var objects []string
+	for _, table := range grant.Tables {
+		objects = append(objects, fmt.Sprintf("%v.%v", database, quoteMySQL(table)))
+	}
+	return objects
+}
+
+func postgreSQLObjects(grant Grant) []string {
+	schema := quotePostgreSQL(postgreSQLSchema(grant))
+	if len(grant.Tables) == 0 {
+		return []string{fmt.Sprintf("ALL TABLES IN SCHEMA %v", schema)}
+	}
+
+	var objects []string
+	for _, table := range grant.Tables {
+		objects = append(objects, fmt.Sprintf("%v.%v", schema, quotePostgreSQL(table)))
+	}
+	return objects
+}
+
+func postgreSQLSchema(grant Grant) string {
+	if grant.Schema == "" {
+		return "public"
+	}
+	return grant.Schema
+}
+
+func quoteMySQL(identifier string) string {
+	return fmt.Sprintf("`%v`", identifier)
+}
+
+func quotePostgreSQL(identifier string) string {
+	return fmt.Sprintf(`"%v"`, identifier)
+}
+
+var (
+	identifierPattern = regexp.MustCompile(`^[A-Za-z0-9_$-]+$`)
+	hostPattern       = regexp.MustCompile(`^[A-Za-z0-9.%_:/-]+$`)
+	privilegePattern  = regexp.MustCompile(`^[A-Za-z]+( [A-Za-z]+)*$`)
+)
+
+func validateGrant(grant Grant) error {
+	if len(grant.Privileges) == 0 {
+		return fmt.Errorf("grant requires at least one privilege")
+	}
+
+	for _, privilege := range grant.Privileges {
+		if !privilegePattern.MatchString(privilege) {
+			return fmt.Errorf("invalid privilege %q", privilege)
+		}
+	}
+
+	identifiers := append([]string{grant.Database, grant.Schema}, grant.Tables...)
+	for _, identifier := range identifiers {
+		if identifier != "" && !identifierPattern.MatchString(identifier) {
+			return fmt.Errorf("invalid identifier %q", identifier)
+		}
+	}
+
+	return nil
+}
+
+var (
+	placeholderPattern = regexp.MustCompile(`{{\s*([^}\s]*)\s*}}`)
+	grantPattern       = regexp.MustCompile(`(?is)^\s*GRANT\s+(.+?)\s+ON\s+(.+?)\s+TO\s+`)
+	revokePattern      = regexp.MustCompile(`(?is)^\s*REVOKE\s+(.+?)\s+ON\s+(.+?)\s+FROM\s+`)
+	revokeAllPattern   = regexp.MustCompile(`(?is)^\s*REVOKE\s+ALL\s+PRIVILEGES\s*,\s*GRANT\s+OPTION\s+FROM\s+`)
+	dropUserPattern    = regexp.MustCompile(`(?is)^\s*DROP\s+(USER|ROLE)\s+`)
+	dropOwnedPattern   = regexp.MustCompile(`(?is)^\s*DROP\s+OWNED\s+BY\s+`)
+)
+
+var knownPlaceholders = map[string]bool{
+	"name":       true,
+	"username":   true,
+	"password":   true,
+	"expiration": true,
+}
+
+// ValidateRoleStatements check role statements placeholders and whether revocation statements undo creation grants.
+// Statements are checked textually, so it only understands the GRANT/REVOKE/DROP forms used by MySQL and PostgreSQL.
+func ValidateRoleStatements(databaseType DatabaseType, role DatabaseRole) (issues []StatementIssue) {
+	issues = []StatementIssue{}
+
+	fields := []struct {
+		name       string
+		statements []string
+	}{
+		{"creation_statements", role.CreationStatements},
+		{"revocation_statements", role.RevocationStatements},
+		{"rollback_statements", role.RollbackStatements},
+		{"renew_statements", role.RenewStatements},
+	}
+
+	for _, field := range fields {
+		for i, statement := range field.statements {
+			for _, match := range placeholderPattern.FindAllStringSubmatch(statement, -1) {
+				if !knownPlaceholders[match[1]] {
+					issues = append(issues, StatementIssue{field.name, i, IssueError, fmt.Sprintf("unknown placeholder %v", match[0])})
+				}
+			}
+		}
+	}
+
+	if len(role.CreationStatements) == 0 {
+		issues = append(issues, StatementIssue{"creation_statements", 0, IssueError, "creation statements are empty"})
+		return
+	}
+
+	creation := strings.Join(role.CreationStatements, "\n")
+	if !hasPlaceholder(creation, "name") && !hasPlaceholder(creation, "username") {
+		issues = append(issues, StatementIssue{"creation_statements", 0, IssueError, "creation statements do not use {{name}}"})
+	}
+	if !hasPlaceholder(creation, "password") {
+		issues = append(issues, StatementIssue{"creation_statements", 0, IssueError, "creation statements do not use {{password}}"})
+	}
+	if databaseType == PostgreSQL && !hasPlaceholder(creation, "expiration") {
+		issues = append(issues, StatementIssue{"creation_statements", 0, IssueWarning, "creation statements do not use {{expiration}}, user will not expire on the database"})
+	}
+
+	for i, statement := range role.RenewStatements {
+		if !hasPlaceholder(statement, "expiration") {
+			issues = append(issues, StatementIssue{"renew_statements", i, IssueWarning, "renew statement does not use {{expiration}}"})
+		}
+	}
+
+	if len(role.RevocationStatements) == 0 {
+		issues = append(issues, StatementIssue{"revocation_statements", 0, IssueWarning, "revocation statements are empty, plugin default is used"})
+		return
+	}
+
+	return append(issues, revocationIssues(databaseType, role)...)
+}
+
+func revocationIssues(databaseType DatabaseType, role DatabaseRole) (issues []StatementIssue) {
+	dropped, revokedAll, droppedOwned := false, false, false
+	revoked := map[string]bool{}
+	for _, statement := range role.RevocationStatements {
+		switch {
+		case dropUserPattern.MatchString(statement):
+			dropped = true
+		case revokeAllPattern.MatchString(statement):
+			revokedAll = true
+		case dropOwnedPattern.MatchString(statement):
+			droppedOwned = true
+		default:
+			if match := revokePattern.FindStringSubmatch(statement); match != nil {
+				revoked[normalizeObject(match[2])] = true
+			}
+		}
+	}
+
+	if !dropped {
+		issues = append(issues, StatementIssue{"revocation_statements", 0, IssueWarning, "revocation statements never drop the user"})
+	}
+
+	// dropping MySQL user removes its privileges, PostgreSQL refuses to drop roles that still hold privileges
+	if revokedAll || droppedOwned || (databaseType != PostgreSQL && dropped) {
+		return
+	}
+
+	for i, statement := range role.CreationStatements {
+		match := grantPattern.FindStringSubmatch(statement)
+		if match == nil {
+			continue
+		}
+
+		if !revoked[normalizeObject(match[2])] {
+			issues = append(issues, StatementIssue{"creation_statements", i, IssueWarning,
+				fmt.Sprintf("grant on %v is not revoked by revocation statements", strings.TrimSpace(match[2]))})
+		}
+	}
+	return
+}
+
+func hasPlaceholder(statement string, name string) bool {
+	for _, match := range placeholderPattern.FindAllStringSubmatch(statement, -1) {
+		if match[1] == name {
+			return true
+		}
+	}
+	return false
+}
+
+func normalizeObject(object string) string {
+	object = strings.ToLower(strings.Join(strings.Fields(object), " "))
+	return strings.NewReplacer("`", "", `"`, "").Replace(object)
+}
@@ -0,0 +1,162 @@
+package client_test
+
+import (
+	"fmt"
+	. "github.com/jasoet/vault-client/pkg/client"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestRoleBuilder(t *testing.T) {
+	t.Run("should build mysql role from grants", func(t *testing.T) {
+		role, err := NewMySQLRole("mysql-db", Grant{Database: "vault", Tables: []string{"users", "orders"}, Privileges: []string{"SELECT", "INSERT"}}).
+			WithGrant(Grant{Privileges: []string{"PROCESS"}}).
+			WithTtl(60, 600).
+			Build()
+		require.NoError(t, err)
+
+		assert.Equal(t, "mysql-db", role.ConnectionName)
+		assert.Equal(t, 60, role.DefaultTtl)
+		assert.Equal(t, 600, role.MaxTtl)
+		assert.Equal(t, []string{
+			"CREATE USER '{{name}}'@'%' IDENTIFIED BY '{{password}}';",
+			"GRANT SELECT, INSERT ON `vault`.`users` TO '{{name}}'@'%';",
+			"GRANT SELECT, INSERT ON `vault`.`orders` TO '{{name}}'@'%';",
+			"GRANT PROCESS ON *.* TO '{{name}}'@'%';",
+		}, role.CreationStatements)
+		assert.Equal(t, []string{
+			"REVOKE ALL PRIVILEGES, GRANT OPTION FROM '{{name}}'@'%';",
+			"DROP USER '{{name}}'@'%';",
+		}, role.RevocationStatements)
+		assert.Equal(t, []string{"DROP USER '{{name}}'@'%';"}, role.RollbackStatements)
+		assert.Empty(t, role.RenewStatements)
+	})
+
+	t.Run("should use custom mysql host", func(t *testing.T) {
+		role, err := NewMySQLRole("mysql-db", Grant{Database: "vault", Privileges: []string{"SELECT"}}).WithHost("10.0.0.%").Build()
+		require.NoError(t, err)
+		assert.Equal(t, "GRANT SELECT ON `vault`.* TO '{{name}}'@'10.0.0.%';", role.CreationStatements[1])
+	})
+
+	t.Run("should build postgresql role from grants", func(t *testing.T) {
+		role, err := NewPostgreSQLRole("pg-db", Grant{Database: "app", Schema: "sales", Privileges: []string{"SELECT"}}).Build()
+		require.NoError(t, err)
+
+		assert.Equal(t, []string{
+			`CREATE ROLE "{{name}}" WITH LOGIN PASSWORD '{{password}}' VALID UNTIL '{{expiration}}';`,
+			`GRANT CONNECT ON DATABASE "app" TO "{{name}}";`,
+			`GRANT USAGE ON SCHEMA "sales" TO "{{name}}";`,
+			`GRANT SELECT ON ALL TABLES IN SCHEMA "sales" TO "{{name}}";`,
+		}, role.CreationStatements)
+		assert.Equal(t, []string{
+			`REVOKE ALL PRIVILEGES ON ALL TABLES IN SCHEMA "sales" FROM "{{name}}";`,
+			`REVOKE USAGE ON SCHEMA "sales" FROM "{{name}}";`,
+			`REVOKE CONNECT ON DATABASE "app" FROM "{{name}}";`,
+			`DROP ROLE IF EXISTS "{{name}}";`,
+		}, role.RevocationStatements)
+		assert.Equal(t, []string{`ALTER ROLE "{{name}}" VALID UNTIL '{{expiration}}';`}, role.RenewStatements)
+		assert.Equal(t, role.RevocationStatements, role.RollbackStatements)
+		assert.Empty(t, ValidateRoleStatements(PostgreSQL, role))
+	})
+
+	t.Run("should use public schema and specific tables on postgresql", func(t *testing.T) {
+		role, err := NewPostgreSQLRole("pg-db", Grant{Tables: []string{"users"}, Privileges: []string{"SELECT", "UPDATE"}}).Build()
+		require.NoError(t, err)
+		assert.Equal(t, `GRANT SELECT, UPDATE ON "public"."users" TO "{{name}}";`, role.CreationStatements[2])
+	})
+
+	t.Run("should reject invalid grants", func(t *testing.T) {
+		_, err := NewMySQLRole("mysql-db").Build()
+		assert.NotNil(t, err)
+
+		_, err = NewMySQLRole("mysql-db", Grant{Database: "vault"}).Build()
+		assert.NotNil(t, err)
+
+		_, err = NewMySQLRole("mysql-db", Grant{Privileges: []string{"SELECT; DROP DATABASE vault"}}).Build()
+		assert.NotNil(t, err)
+
+		_, err = NewPostgreSQLRole("pg-db", Grant{Tables: []string{`users"; DROP TABLE users; --`}, Privileges: []string{"SELECT"}}).Build()
+		assert.NotNil(t, err)
+	})
+
+	t.Run("should reject unsafe mysql host", func(t *testing.T) {
+		grant := Grant{Database: "vault", Privileges: []string{"SELECT"}}
+		for _, host := range []string{"", "%'; DROP USER 'root'@'%", "10.0.0.1 ", "host`"} {
+			_, err := NewMySQLRole("mysql-db", grant).WithHost(host).Build()
+			assert.EqualError(t, err, fmt.Sprintf("invalid host %q", host))
+		}
+
+		for _, host := range []string{"%", "localhost", "app-1.example.com", "192.168.0.0/255.255.255.0", "::1", "10.0.%"} {
+			_, err := NewMySQLRole("mysql-db", grant).WithHost(host).Build()
+			assert.NoError(t, err, host)
+		}
+	})
+}
+
+func TestValidateRoleStatements(t *testing.T) {
+	t.Run("should accept statements used by the example", func(t *testing.T) {
+		role := DatabaseRole{
+			CreationStatements:   []string{"CREATE USER '{{name}}'@'%' IDENTIFIED BY '{{password}}';", "GRANT SELECT ON *.* TO '{{name}}'@'%';"},
+			RevocationStatements: []string{"DROP USER '{{name}}'@'%';"},
+		}
+		assert.Empty(t, ValidateRoleStatements(MySQL, role))
+	})
+
+	t.Run("should report missing and unknown placeholders", func(t *testing.T) {
+		role := DatabaseRole{
+			CreationStatements:   []string{"CREATE USER '{{user}}'@'%';"},
+			RevocationStatements: []string{"DROP USER '{{name}}'@'%';"},
+		}
+
+		issues := ValidateRoleStatements(MySQL, role)
+		require.Len(t, issues, 3)
+		assert.Equal(t, StatementIssue{"creation_statements", 0, IssueError, "unknown placeholder {{user}}"}, issues[0])
+		assert.Equal(t, "creation statements do not use {{name}}", issues[1].Message)
+		assert.Equal(t, "creation statements do not use {{password}}", issues[2].Message)
+	})
+
+	t.Run("should warn on postgresql statements without expiration", func(t *testing.T) {
+		role := DatabaseRole{
+			CreationStatements:   []string{`CREATE ROLE "{{name}}" WITH LOGIN PASSWORD '{{password}}';`},
+			RevocationStatements: []string{`DROP ROLE "{{name}}";`},
+			RenewStatements:      []string{`ALTER ROLE "{{name}}" VALID UNTIL 'infinity';`},
+		}
+
+		issues := ValidateRoleStatements(PostgreSQL, role)
+		require.Len(t, issues, 2)
+		assert.Equal(t, IssueWarning, issues[0].Severity)
+		assert.Equal(t, "creation_statements", issues[0].Field)
+		assert.Equal(t, "renew_statements", issues[1].Field)
+	})
+
+	t.Run("should flag postgresql grants not revoked before dropping role", func(t *testing.T) {
+		role := DatabaseRole{
+			CreationStatements: []string{
+				`CREATE ROLE "{{name}}" WITH LOGIN PASSWORD '{{password}}' VALID UNTIL '{{expiration}}';`,
+				`GRANT SELECT ON ALL TABLES IN SCHEMA public TO "{{name}}";`,
+				`GRANT USAGE ON SCHEMA public TO "{{name}}";`,
+			},
+			RevocationStatements: []string{
+				`REVOKE ALL ON ALL TABLES IN SCHEMA "public" FROM "{{name}}";`,
+				`DROP ROLE "{{name}}";`,
+			},
+		}
+
+		issues := ValidateRoleStatements(PostgreSQL, role)
+		require.Len(t, issues, 1)
+		assert.Equal(t, StatementIssue{"creation_statements", 2, IssueWarning, "grant on SCHEMA public is not revoked by revocation statements"}, issues[0])
+	})
+
+	t.Run("should flag mysql revocation that neither drop user nor revoke grants", func(t *testing.T) {
+		role := DatabaseRole{
+			CreationStatements:   []string{"CREATE USER '{{name}}'@'%' IDENTIFIED BY '{{password}}';", "GRANT SELECT ON vault.* TO '{{name}}'@'%';"},
+			RevocationStatements: []string{"REVOKE INSERT ON other.* FROM '{{name}}'@'%';"},
+		}
+
+		issues := ValidateRoleStatements(MySQL, role)
+		require.Len(t, issues, 2)
+		assert.Equal(t, "revocation statements never drop the user", issues[0].Message)
+		assert.Equal(t, "grant on vault.* is not revoked by revocation statements", issues[1].Message)
+	})
+}
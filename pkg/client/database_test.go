@@ -67,6 +67,40 @@ func TestDatabase(t *testing.T) {
 		assert.Equal(t, databaseConfig.Username, config.Username)
 	})
 
+	t.Run("plugin options should round trip through read config", func(t *testing.T) {
+		optionsConnectionName := "test-db-options"
+		verifyConnection := true
+		options := &MySQLOptions{
+			SQLOptions: SQLOptions{
+				MaxOpenConnections:    5,
+				MaxIdleConnections:    2,
+				MaxConnectionLifetime: "30s",
+				UsernameTemplate:      "{{.RoleName}}-{{random 8}}",
+			},
+			TlsServerName: "db",
+		}
+
+		err := database.CreateConnection(optionsConnectionName, DatabaseConfig{
+			Type:             MySQL,
+			ConnectionUrl:    databaseConfig.ConnectionUrl,
+			Username:         databaseConfig.Username,
+			Password:         databaseConfig.Password,
+			AllowedRoles:     []string{roleName},
+			VerifyConnection: &verifyConnection,
+			Options:          options,
+		})
+		assert.Nil(t, err)
+
+		config, err := database.ReadConnection(optionsConnectionName)
+		assert.Nil(t, err)
+		assert.NotNil(t, config)
+		assert.Equal(t, databaseConfig.ConnectionUrl, config.ConnectionUrl)
+		assert.Equal(t, options, config.Options)
+
+		err = database.DeleteConnection(optionsConnectionName)
+		assert.Nil(t, err)
+	})
+
 	t.Run("reset config should not produce error", func(t *testing.T) {
 		err := database.ResetConnection(connectionName)
 		assert.Nil(t, err)
@@ -142,6 +176,7 @@ func TestDatabase(t *testing.T) {
 		cred, err := database.GenerateCreds(roleName)
 		assert.Nil(t, err)
 		assert.NotNil(t, cred)
+		assert.Equal(t, PasswordCredential, cred.CredentialType)
 		assert.NotNil(t, cred.Username)
 		assert.NotNil(t, cred.Password)
 		assert.NotNil(t, cred.LeaseDuration)
@@ -165,4 +200,108 @@ func TestDatabase(t *testing.T) {
 
 	})
 
+	staticRoleName := "test-static-role"
+	staticRole := DatabaseStaticRole{
+		ConnectionName: connectionName,
+		Username:       "vault",
+		RotationPeriod: 3600,
+	}
+
+	t.Run("create static role should not produce error", func(t *testing.T) {
+		err = database.CreateConnection(connectionName, DatabaseConfig{
+			Type:          databaseConfig.Type,
+			ConnectionUrl: databaseConfig.ConnectionUrl,
+			Username:      databaseConfig.Username,
+			Password:      databaseConfig.Password,
+			AllowedRoles:  []string{roleName, staticRoleName},
+		})
+		assert.Nil(t, err)
+
+		err = database.CreateStaticRole(staticRoleName, staticRole)
+		assert.Nil(t, err)
+	})
+
+	t.Run("fetch static role should return correct values", func(t *testing.T) {
+		detail, err := database.ReadStaticRole(staticRoleName)
+		assert.Nil(t, err)
+		assert.NotNil(t, detail)
+		assert.Equal(t, staticRole.ConnectionName, detail.ConnectionName)
+		assert.Equal(t, staticRole.Username, detail.Username)
+		assert.Equal(t, staticRole.RotationPeriod, detail.RotationPeriod)
+		assert.NotNil(t, detail.LastVaultRotation)
+
+		list, err := database.ListStaticRole()
+		assert.Nil(t, err)
+		assert.Contains(t, list, staticRoleName)
+	})
+
+	t.Run("rotate static role should change password", func(t *testing.T) {
+		creds, err := database.ReadStaticCreds(staticRoleName)
+		assert.Nil(t, err)
+		assert.NotNil(t, creds)
+		assert.Equal(t, staticRole.Username, creds.Username)
+		assert.NotEmpty(t, creds.Password)
+		assert.Greater(t, creds.Ttl, 0)
+
+		err = database.RotateStaticRole(staticRoleName)
+		assert.Nil(t, err)
+
+		rotated, err := database.ReadStaticCreds(staticRoleName)
+		assert.Nil(t, err)
+		assert.NotNil(t, rotated)
+		assert.NotEqual(t, creds.Password, rotated.Password)
+		assert.False(t, rotated.LastVaultRotation.Before(creds.LastVaultRotation))
+	})
+
+	t.Run("cannot fetch deleted static role", func(t *testing.T) {
+		err := database.DeleteStaticRole(staticRoleName)
+		assert.Nil(t, err)
+
+		detail, err := database.ReadStaticRole(staticRoleName)
+		assert.NotNil(t, err)
+		assert.Nil(t, detail)
+	})
+
+	t.Run("rotate root should not produce error", func(t *testing.T) {
+		// use generated creds as root user, to keep the real root password usable by other tests
+		cred, err := database.GenerateCreds(roleName)
+		assert.Nil(t, err)
+		assert.NotNil(t, cred)
+
+		rotateConnectionName := "test-db-rotate"
+		err = database.CreateConnection(rotateConnectionName, DatabaseConfig{
+			Type:          databaseConfig.Type,
+			ConnectionUrl: databaseConfig.ConnectionUrl,
+			Username:      cred.Username,
+			Password:      cred.Password,
+			AllowedRoles:  []string{},
+		})
+		assert.Nil(t, err)
+
+		err = database.RotateRoot(rotateConnectionName)
+		assert.Nil(t, err)
+
+		err = database.DeleteConnection(rotateConnectionName)
+		assert.Nil(t, err)
+	})
+
+	t.Run("wrapped creds should be unwrapped once", func(t *testing.T) {
+		info, err := database.GenerateCredsWrapped(roleName, WrapOptions{Ttl: 300})
+		assert.Nil(t, err)
+		assert.NotNil(t, info)
+		assert.NotEmpty(t, info.Token)
+		assert.Equal(t, 300, info.Ttl)
+
+		cred, err := database.UnwrapCreds(info.Token)
+		assert.Nil(t, err)
+		assert.NotNil(t, cred)
+		assert.NotEmpty(t, cred.Username)
+		assert.NotEmpty(t, cred.Password)
+		assert.NotEmpty(t, cred.LeaseId)
+
+		cred, err = database.UnwrapCreds(info.Token)
+		assert.NotNil(t, err)
+		assert.Nil(t, cred)
+	})
+
 }
@@ -0,0 +1,314 @@
+package client
+
+import (
+	"fmt"
+	"github.com/hashicorp/vault/api"
+	"github.com/jasoet/vault-client/pkg/util"
+)
+
+type identityEngine struct {
+	vaultClient *api.Client
+}
+
+func (i identityEngine) CreateEntity(entity IdentityEntity) (created *IdentityEntity, err error) {
+	payload, err := util.StructToMapE(entity)
+	if err != nil {
+		return
+	}
+
+	result, err := i.vaultClient.Logical().Write("identity/entity", payload)
+	if err != nil {
+		return
+	}
+
+	// existing entity with the same name is updated, Vault does not return its id
+	if result == nil {
+		return i.ReadEntityByName(entity.Name)
+	}
+
+	return i.ReadEntity(fmt.Sprint(result.Data["id"]))
+}
+
+func (i identityEngine) ReadEntity(id string) (entity *IdentityEntity, err error) {
+	entity = new(IdentityEntity)
+	err = i.read(fmt.Sprintf("identity/entity/id/%v", id), entity)
+	if err != nil {
+		entity = nil
+	}
+	return
+}
+
+func (i identityEngine) ReadEntityByName(name string) (entity *IdentityEntity, err error) {
+	entity = new(IdentityEntity)
+	err = i.read(fmt.Sprintf("identity/entity/name/%v", name), entity)
+	if err != nil {
+		entity = nil
+	}
+	return
+}
+
+func (i identityEngine) UpdateEntity(id string, entity IdentityEntity) (err error) {
+	payload, err := util.StructToMapE(entity)
+	if err != nil {
+		return
+	}
+
+	_, err = i.vaultClient.Logical().Write(fmt.Sprintf("identity/entity/id/%v", id), payload)
+	return
+}
+
+func (i identityEngine) DeleteEntity(id string) (err error) {
+	_, err = i.vaultClient.Logical().Delete(fmt.Sprintf("identity/entity/id/%v", id))
+	return
+}
+
+func (i identityEngine) ListEntity() ([]string, error) {
+	return i.list("identity/entity/id")
+}
+
+func (i identityEngine) ListEntityName() ([]string, error) {
+	return i.list("identity/entity/name")
+}
+
+// MergeEntities merge fromEntityIds into toEntityId, force is required when the entities have aliases on the same mount
+func (i identityEngine) MergeEntities(toEntityId string, fromEntityIds []string, force bool) (err error) {
+	payload := map[string]interface{}{
+		"to_entity_id":    toEntityId,
+		"from_entity_ids": fromEntityIds,
+		"force":           force,
+	}
+	_, err = i.vaultClient.Logical().Write("identity/entity/merge", payload)
+	return
+}
+
+func (i identityEngine) CreateEntityAlias(alias IdentityEntityAlias) (created *IdentityEntityAlias, err error) {
+	payload, err := util.StructToMapE(alias)
+	if err != nil {
+		return
+	}
+
+	result, err := i.vaultClient.Logical().Write("identity/entity-alias", payload)
+	if err != nil {
+		return
+	}
+
+	if result == nil {
+		err = fmt.Errorf("entity alias %v is not created", alias.Name)
+		return
+	}
+
+	return i.ReadEntityAlias(fmt.Sprint(result.Data["id"]))
+}
+
+func (i identityEngine) ReadEntityAlias(id string) (alias *IdentityEntityAlias, err error) {
+	alias = new(IdentityEntityAlias)
+	err = i.read(fmt.Sprintf("identity/entity-alias/id/%v", id), alias)
+	if err != nil {
+		alias = nil
+	}
+	return
+}
+
+func (i identityEngine) UpdateEntityAlias(id string, alias IdentityEntityAlias) (err error) {
+	payload, err := util.StructToMapE(alias)
+	if err != nil {
+		return
+	}
+
+	_, err = i.vaultClient.Logical().Write(fmt.Sprintf("identity/entity-alias/id/%v", id), payload)
+	return
+}
+
+func (i identityEngine) DeleteEntityAlias(id string) (err error) {
+	_, err = i.vaultClient.Logical().Delete(fmt.Sprintf("identity/entity-alias/id/%v", id))
+	return
+}
+
+func (i identityEngine) ListEntityAlias() ([]string, error) {
+	return i.list("identity/entity-alias/id")
+}
+
+func (i identityEngine) CreateGroup(group IdentityGroup) (created *IdentityGroup, err error) {
+	payload, err := util.StructToMapE(group)
+	if err != nil {
+		return
+	}
+
+	result, err := i.vaultClient.Logical().Write("identity/group", payload)
+	if err != nil {
+		return
+	}
+
+	// existing group with the same name is updated, Vault does not return its id
+	if result == nil {
+		return i.ReadGroupByName(group.Name)
+	}
+
+	return i.ReadGroup(fmt.Sprint(result.Data["id"]))
+}
+
+func (i identityEngine) ReadGroup(id string) (group *IdentityGroup, err error) {
+	group = new(IdentityGroup)
+	err = i.read(fmt.Sprintf("identity/group/id/%v", id), group)
+	if err != nil {
+		group = nil
+	}
+	return
+}
+
+func (i identityEngine) ReadGroupByName(name string) (group *IdentityGroup, err error) {
+	group = new(IdentityGroup)
+	err = i.read(fmt.Sprintf("identity/group/name/%v", name), group)
+	if err != nil {
+		group = nil
+	}
+	return
+}
+
+func (i identityEngine) UpdateGroup(id string, group IdentityGroup) (err error) {
+	payload, err := util.StructToMapE(group)
+	if err != nil {
+		return
+	}
+
+	_, err = i.vaultClient.Logical().Write(fmt.Sprintf("identity/group/id/%v", id), payload)
+	return
+}
+
+func (i identityEngine) DeleteGroup(id string) (err error) {
+	_, err = i.vaultClient.Logical().Delete(fmt.Sprintf("identity/group/id/%v", id))
+	return
+}
+
+func (i identityEngine) ListGroup() ([]string, error) {
+	return i.list("identity/group/id")
+}
+
+func (i identityEngine) ListGroupName() ([]string, error) {
+	return i.list("identity/group/name")
+}
+
+func (i identityEngine) CreateGroupAlias(alias IdentityGroupAlias) (created *IdentityGroupAlias, err error) {
+	payload, err := util.StructToMapE(alias)
+	if err != nil {
+		return
+	}
+
+	result, err := i.vaultClient.Logical().Write("identity/group-alias", payload)
+	if err != nil {
+		return
+	}
+
+	if result == nil {
+		err = fmt.Errorf("group alias %v is not created", alias.Name)
+		return
+	}
+
+	return i.ReadGroupAlias(fmt.Sprint(result.Data["id"]))
+}
+
+func (i identityEngine) ReadGroupAlias(id string) (alias *IdentityGroupAlias, err error) {
+	alias = new(IdentityGroupAlias)
+	err = i.read(fmt.Sprintf("identity/group-alias/id/%v", id), alias)
+	if err != nil {
+		alias = nil
+	}
+	return
+}
+
+func (i identityEngine) UpdateGroupAlias(id string, alias IdentityGroupAlias) (err error) {
+	payload, err := util.StructToMapE(alias)
+	if err != nil {
+		return
+	}
+
+	_, err = i.vaultClient.Logical().Write(fmt.Sprintf("identity/group-alias/id/%v", id), payload)
+	return
+}
+
+func (i identityEngine) DeleteGroupAlias(id string) (err error) {
+	_, err = i.vaultClient.Logical().Delete(fmt.Sprintf("identity/group-alias/id/%v", id))
+	return
+}
+
+func (i identityEngine) ListGroupAlias() ([]string, error) {
+	return i.list("identity/group-alias/id")
+}
+
+func (i identityEngine) LookupEntity(lookup IdentityLookup) (entity *IdentityEntity, err error) {
+	entity = new(IdentityEntity)
+	err = i.lookup("identity/lookup/entity", lookup, entity)
+	if err != nil {
+		entity = nil
+	}
+	return
+}
+
+func (i identityEngine) LookupGroup(lookup IdentityLookup) (group *IdentityGroup, err error) {
+	group = new(IdentityGroup)
+	err = i.lookup("identity/lookup/group", lookup, group)
+	if err != nil {
+		group = nil
+	}
+	return
+}
+
+func (i identityEngine) read(path string, output interface{}) (err error) {
+	result, err := i.vaultClient.Logical().Read(path)
+	if err != nil {
+		return
+	}
+
+	if result == nil {
+		err = fmt.Errorf("%v is not found", path)
+		return
+	}
+
+	return util.MapToStruct(result.Data, output)
+}
+
+func (i identityEngine) lookup(path string, lookup IdentityLookup, output interface{}) (err error) {
+	payload, err := util.StructToMapE(lookup)
+	if err != nil {
+		return
+	}
+
+	result, err := i.vaultClient.Logical().Write(path, payload)
+	if err != nil {
+		return
+	}
+
+	if result == nil {
+		err = fmt.Errorf("%v did not match anything", path)
+		return
+	}
+
+	return util.MapToStruct(result.Data, output)
+}
+
+func (i identityEngine) list(path string) (list []string, err error) {
+	result, err := i.vaultClient.Logical().List(path)
+	if err != nil || result == nil {
+		return []string{}, nil
+	}
+
+	if val, ok := result.Data["keys"]; ok {
+		list = util.ToArrStr(val.([]interface{}))
+	}
+	return
+}
+
+func DefaultIdentity() (identity Identity, err error) {
+	vaultClient, err := api.NewClient(api.DefaultConfig())
+	if err != nil {
+		return
+	}
+
+	identity = &identityEngine{vaultClient: vaultClient}
+	return
+}
+
+func NewIdentity(vaultClient *api.Client) (identity Identity, err error) {
+	identity = &identityEngine{vaultClient: vaultClient}
+	return
+}
@@ -0,0 +1,155 @@
+package client
+
+import (
+	"fmt"
+	"github.com/jasoet/vault-client/pkg/util"
+	"gopkg.in/go-jose/go-jose.v2"
+)
+
+func (i identityEngine) ConfigureOIDC(config IdentityOIDCConfig) (err error) {
+	payload, err := util.StructToMapE(config)
+	if err != nil {
+		return
+	}
+
+	_, err = i.vaultClient.Logical().Write("identity/oidc/config", payload)
+	return
+}
+
+func (i identityEngine) ReadOIDCConfig() (config *IdentityOIDCConfig, err error) {
+	config = new(IdentityOIDCConfig)
+	err = i.read("identity/oidc/config", config)
+	if err != nil {
+		config = nil
+	}
+	return
+}
+
+func (i identityEngine) CreateOIDCKey(name string, key IdentityOIDCKey) (err error) {
+	payload, err := util.StructToMapE(key)
+	if err != nil {
+		return
+	}
+
+	_, err = i.vaultClient.Logical().Write(fmt.Sprintf("identity/oidc/key/%v", name), payload)
+	return
+}
+
+func (i identityEngine) ReadOIDCKey(name string) (key *IdentityOIDCKey, err error) {
+	key = new(IdentityOIDCKey)
+	err = i.read(fmt.Sprintf("identity/oidc/key/%v", name), key)
+	if err != nil {
+		key = nil
+	}
+	return
+}
+
+// DeleteOIDCKey fail when the key is still referenced by a role
+func (i identityEngine) DeleteOIDCKey(name string) (err error) {
+	_, err = i.vaultClient.Logical().Delete(fmt.Sprintf("identity/oidc/key/%v", name))
+	return
+}
+
+func (i identityEngine) ListOIDCKey() ([]string, error) {
+	return i.list("identity/oidc/key")
+}
+
+// RotateOIDCKey verificationTtl in seconds, zero verificationTtl use key verification_ttl
+func (i identityEngine) RotateOIDCKey(name string, verificationTtl int) (err error) {
+	payload := map[string]interface{}{}
+	if verificationTtl > 0 {
+		payload["verification_ttl"] = verificationTtl
+	}
+	_, err = i.vaultClient.Logical().Write(fmt.Sprintf("identity/oidc/key/%v/rotate", name), payload)
+	return
+}
+
+func (i identityEngine) CreateOIDCRole(name string, role IdentityOIDCRole) (err error) {
+	payload, err := util.StructToMapE(role)
+	if err != nil {
+		return
+	}
+
+	_, err = i.vaultClient.Logical().Write(fmt.Sprintf("identity/oidc/role/%v", name), payload)
+	return
+}
+
+func (i identityEngine) ReadOIDCRole(name string) (role *IdentityOIDCRole, err error) {
+	role = new(IdentityOIDCRole)
+	err = i.read(fmt.Sprintf("identity/oidc/role/%v", name), role)
+	if err != nil {
+		role = nil
+	}
+	return
+}
+
+func (i identityEngine) DeleteOIDCRole(name string) (err error) {
+	_, err = i.vaultClient.Logical().Delete(fmt.Sprintf("identity/oidc/role/%v", name))
+	return
+}
+
+func (i identityEngine) ListOIDCRole() ([]string, error) {
+	return i.list("identity/oidc/role")
+}
+
+// GenerateOIDCToken issue token for the entity of the client token, tokens without entity (e.g. root) are rejected
+func (i identityEngine) GenerateOIDCToken(roleName string) (token *IdentityToken, err error) {
+	token = new(IdentityToken)
+	err = i.read(fmt.Sprintf("identity/oidc/token/%v", roleName), token)
+	if err != nil {
+		token = nil
+	}
+	return
+}
+
+// IntrospectOIDCToken verify signature, expiry and entity of the token, empty clientId skip audience check.
+// Inactive token return error with the reason given by Vault
+func (i identityEngine) IntrospectOIDCToken(token string, clientId string) (active bool, err error) {
+	payload := map[string]interface{}{
+		"token": token,
+	}
+	if clientId != "" {
+		payload["client_id"] = clientId
+	}
+
+	request := i.vaultClient.NewRequest("POST", "/v1/identity/oidc/introspect")
+	err = request.SetJSONBody(payload)
+	if err != nil {
+		return
+	}
+
+	// introspection is returned as raw `{"active": ...}` body without the `data` envelope
+	result := struct {
+		Active bool   `json:"active"`
+		Error  string `json:"error"`
+	}{}
+	err = rawRequest(i.vaultClient, request, &result)
+	if err != nil {
+		return
+	}
+
+	if result.Error != "" {
+		err = fmt.Errorf("token is not active: %v", result.Error)
+		return
+	}
+	return result.Active, nil
+}
+
+// ReadOIDCPublicKeys read JWKS of all OIDC keys including rotated keys within verification_ttl
+func (i identityEngine) ReadOIDCPublicKeys() (keySet *jose.JSONWebKeySet, err error) {
+	request := i.vaultClient.NewRequest("GET", "/v1/identity/oidc/.well-known/keys")
+	response, err := i.vaultClient.RawRequest(request)
+	if response != nil {
+		defer response.Body.Close()
+	}
+	if err != nil {
+		return
+	}
+
+	keySet = new(jose.JSONWebKeySet)
+	err = response.DecodeJSON(keySet)
+	if err != nil {
+		keySet = nil
+	}
+	return
+}
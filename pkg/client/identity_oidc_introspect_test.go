@@ -0,0 +1,56 @@
+package client_test
+
+import (
+	"encoding/json"
+	"github.com/hashicorp/vault/api"
+	. "github.com/jasoet/vault-client/pkg/client"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// introspectServer answer identity/oidc/introspect with raw body like Vault does
+func introspectServer(t *testing.T, body string) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/v1/identity/oidc/introspect", r.URL.Path)
+
+		payload := map[string]string{}
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&payload))
+		assert.Equal(t, "token", payload["token"])
+		assert.Equal(t, "client-a", payload["client_id"])
+
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(body))
+	}))
+}
+
+func TestIdentityIntrospectOIDCToken(t *testing.T) {
+	newIdentity := func(address string) Identity {
+		vaultClient, err := api.NewClient(&api.Config{Address: address})
+		require.NoError(t, err)
+
+		identity, err := NewIdentity(vaultClient)
+		require.NoError(t, err)
+		return identity
+	}
+
+	t.Run("should return active token", func(t *testing.T) {
+		server := introspectServer(t, `{"active":true}`)
+		defer server.Close()
+
+		active, err := newIdentity(server.URL).IntrospectOIDCToken("token", "client-a")
+		assert.NoError(t, err)
+		assert.True(t, active)
+	})
+
+	t.Run("should return error reason of inactive token", func(t *testing.T) {
+		server := introspectServer(t, `{"active":false,"error":"token is expired"}`)
+		defer server.Close()
+
+		active, err := newIdentity(server.URL).IntrospectOIDCToken("token", "client-a")
+		assert.EqualError(t, err, "token is not active: token is expired")
+		assert.False(t, active)
+	})
+}
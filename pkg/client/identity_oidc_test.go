@@ -0,0 +1,159 @@
+// +build integration
+
+package client_test
+
+import (
+	"github.com/hashicorp/vault/api"
+	. "github.com/jasoet/vault-client/pkg/client"
+	"github.com/stretchr/testify/assert"
+	"os"
+	"testing"
+)
+
+type identityOIDCTestCtx struct {
+	vaultClient *api.Client
+}
+
+func (ctx *identityOIDCTestCtx) setup(t *testing.T) {
+	config := &api.Config{Address: os.Getenv("TEST_VAULT_ADDR")}
+	client, err := api.NewClient(config)
+	assert.Nil(t, err)
+
+	client.SetToken(os.Getenv("TEST_VAULT_TOKEN"))
+
+	ctx.vaultClient = client
+}
+
+// entityClient login to userpass so the returned client token is backed by an entity
+func (ctx *identityOIDCTestCtx) entityClient(t *testing.T) *api.Client {
+	authPath := "identity-oidc-userpass"
+	_ = ctx.vaultClient.Sys().EnableAuthWithOptions(authPath, &api.EnableAuthOptions{Type: "userpass"})
+
+	_, err := ctx.vaultClient.Logical().Write("auth/"+authPath+"/users/service-a", map[string]interface{}{
+		"password": "service-a-password",
+		"policies": "identity-oidc-token",
+	})
+	assert.Nil(t, err)
+
+	err = ctx.vaultClient.Sys().PutPolicy("identity-oidc-token", `path "identity/oidc/token/*" { capabilities = ["read"] }`)
+	assert.Nil(t, err)
+
+	secret, err := ctx.vaultClient.Logical().Write("auth/"+authPath+"/login/service-a", map[string]interface{}{
+		"password": "service-a-password",
+	})
+	assert.Nil(t, err)
+
+	client, err := ctx.vaultClient.Clone()
+	assert.Nil(t, err)
+	client.SetToken(secret.Auth.ClientToken)
+	return client
+}
+
+func TestIdentityOIDC(t *testing.T) {
+	ctx := new(identityOIDCTestCtx)
+	ctx.setup(t)
+
+	engine, err := NewIdentity(ctx.vaultClient)
+	assert.Nil(t, err)
+
+	keyName := "service-key"
+	roleName := "service-role"
+
+	t.Run("should create and read key", func(t *testing.T) {
+		err := engine.CreateOIDCKey(keyName, IdentityOIDCKey{
+			RotationPeriod:   86400,
+			VerificationTtl:  86400,
+			AllowedClientIds: []string{"*"},
+			Algorithm:        "RS256",
+		})
+		assert.Nil(t, err)
+
+		key, err := engine.ReadOIDCKey(keyName)
+		assert.Nil(t, err)
+		assert.Equal(t, 86400, key.RotationPeriod)
+		assert.Equal(t, []string{"*"}, key.AllowedClientIds)
+
+		list, err := engine.ListOIDCKey()
+		assert.Nil(t, err)
+		assert.Contains(t, list, keyName)
+	})
+
+	var role *IdentityOIDCRole
+	t.Run("should create role with generated client id", func(t *testing.T) {
+		err := engine.CreateOIDCRole(roleName, IdentityOIDCRole{Key: keyName, Ttl: 600})
+		assert.Nil(t, err)
+
+		role, err = engine.ReadOIDCRole(roleName)
+		assert.Nil(t, err)
+		assert.Equal(t, keyName, role.Key)
+		assert.NotEmpty(t, role.ClientId)
+
+		list, err := engine.ListOIDCRole()
+		assert.Nil(t, err)
+		assert.Contains(t, list, roleName)
+	})
+
+	t.Run("token without entity should not get identity token", func(t *testing.T) {
+		token, err := engine.GenerateOIDCToken(roleName)
+		assert.NotNil(t, err)
+		assert.Nil(t, token)
+	})
+
+	var token *IdentityToken
+	t.Run("should issue, introspect and verify identity token", func(t *testing.T) {
+		serviceEngine, err := NewIdentity(ctx.entityClient(t))
+		assert.Nil(t, err)
+
+		token, err = serviceEngine.GenerateOIDCToken(roleName)
+		assert.Nil(t, err)
+		assert.Equal(t, role.ClientId, token.ClientId)
+		assert.NotEmpty(t, token.Token)
+
+		active, err := engine.IntrospectOIDCToken(token.Token, role.ClientId)
+		assert.Nil(t, err)
+		assert.True(t, active)
+
+		config, err := engine.ReadOIDCConfig()
+		assert.Nil(t, err)
+
+		verifier, err := NewOIDCVerifier(engine, OIDCVerifierOptions{ClientId: role.ClientId})
+		assert.Nil(t, err)
+
+		claims, err := verifier.Verify(token.Token)
+		assert.Nil(t, err)
+		assert.NotEmpty(t, claims.Subject)
+		if config.Issuer == "" {
+			assert.Contains(t, claims.Issuer, "/v1/identity/oidc")
+		}
+	})
+
+	t.Run("rotated key should still verify token within verification ttl", func(t *testing.T) {
+		err := engine.RotateOIDCKey(keyName, 0)
+		assert.Nil(t, err)
+
+		keys, err := engine.ReadOIDCPublicKeys()
+		assert.Nil(t, err)
+		assert.True(t, len(keys.Keys) >= 2)
+
+		verifier, err := NewOIDCVerifier(engine, OIDCVerifierOptions{ClientId: role.ClientId})
+		assert.Nil(t, err)
+
+		claims, err := verifier.Verify(token.Token)
+		assert.Nil(t, err)
+		assert.NotNil(t, claims)
+	})
+
+	t.Run("cannot fetch deleted role and key", func(t *testing.T) {
+		err := engine.DeleteOIDCRole(roleName)
+		assert.Nil(t, err)
+		deletedRole, err := engine.ReadOIDCRole(roleName)
+		assert.NotNil(t, err)
+		assert.Nil(t, deletedRole)
+
+		err = engine.DeleteOIDCKey(keyName)
+		assert.Nil(t, err)
+		deletedKey, err := engine.ReadOIDCKey(keyName)
+		assert.NotNil(t, err)
+		assert.Nil(t, deletedKey)
+	})
+}
@@ -0,0 +1,187 @@
+// +build integration
+
+package client_test
+
+import (
+	"github.com/hashicorp/vault/api"
+	. "github.com/jasoet/vault-client/pkg/client"
+	"github.com/stretchr/testify/assert"
+	"os"
+	"testing"
+)
+
+type identityTestCtx struct {
+	vaultClient *api.Client
+}
+
+func (ctx *identityTestCtx) setup(t *testing.T) {
+	config := &api.Config{Address: os.Getenv("TEST_VAULT_ADDR")}
+	client, err := api.NewClient(config)
+	assert.Nil(t, err)
+
+	client.SetToken(os.Getenv("TEST_VAULT_TOKEN"))
+
+	ctx.vaultClient = client
+}
+
+func TestIdentity(t *testing.T) {
+	ctx := new(identityTestCtx)
+	ctx.setup(t)
+
+	engine, err := NewIdentity(ctx.vaultClient)
+	assert.Nil(t, err)
+	assert.NotNil(t, engine)
+
+	// Setup userpass auth to get mount accessor for aliases
+	authPath := "identity-userpass"
+	_ = ctx.vaultClient.Sys().EnableAuthWithOptions(authPath, &api.EnableAuthOptions{Type: "userpass"})
+	auths, err := ctx.vaultClient.Sys().ListAuth()
+	assert.Nil(t, err)
+	mountAccessor := auths[authPath+"/"].Accessor
+
+	entityInput := IdentityEntity{
+		Name:     "identity-user",
+		Metadata: map[string]string{"team": "platform"},
+		Policies: []string{"default"},
+	}
+
+	var entity *IdentityEntity
+	t.Run("create entity should return created entity", func(t *testing.T) {
+		entity, err = engine.CreateEntity(entityInput)
+		assert.Nil(t, err)
+		assert.NotNil(t, entity)
+		assert.NotEmpty(t, entity.Id)
+		assert.Equal(t, entityInput.Name, entity.Name)
+		assert.Equal(t, entityInput.Metadata, entity.Metadata)
+		assert.NotNil(t, entity.CreationTime)
+	})
+
+	t.Run("entity should be found by name, id and list", func(t *testing.T) {
+		byName, err := engine.ReadEntityByName(entityInput.Name)
+		assert.Nil(t, err)
+		assert.Equal(t, entity.Id, byName.Id)
+
+		byLookup, err := engine.LookupEntity(IdentityLookup{Id: entity.Id})
+		assert.Nil(t, err)
+		assert.Equal(t, entity.Name, byLookup.Name)
+
+		ids, err := engine.ListEntity()
+		assert.Nil(t, err)
+		assert.Contains(t, ids, entity.Id)
+
+		names, err := engine.ListEntityName()
+		assert.Nil(t, err)
+		assert.Contains(t, names, entity.Name)
+	})
+
+	t.Run("partial update should keep entity disabled", func(t *testing.T) {
+		disabled := true
+		err := engine.UpdateEntity(entity.Id, IdentityEntity{Name: entityInput.Name, Disabled: &disabled})
+		assert.Nil(t, err)
+
+		err = engine.UpdateEntity(entity.Id, IdentityEntity{Name: entityInput.Name, Policies: []string{"default", "reader"}})
+		assert.Nil(t, err)
+
+		updated, err := engine.ReadEntity(entity.Id)
+		assert.Nil(t, err)
+		assert.Equal(t, []string{"default", "reader"}, updated.Policies)
+		assert.NotNil(t, updated.Disabled)
+		assert.True(t, *updated.Disabled)
+
+		enabled := false
+		err = engine.UpdateEntity(entity.Id, IdentityEntity{Name: entityInput.Name, Disabled: &enabled})
+		assert.Nil(t, err)
+	})
+
+	var alias *IdentityEntityAlias
+	t.Run("entity alias should be attached to entity", func(t *testing.T) {
+		alias, err = engine.CreateEntityAlias(IdentityEntityAlias{Name: "identity-login", CanonicalId: entity.Id, MountAccessor: mountAccessor})
+		assert.Nil(t, err)
+		assert.NotNil(t, alias)
+		assert.Equal(t, entity.Id, alias.CanonicalId)
+		assert.Equal(t, "userpass", alias.MountType)
+
+		byAlias, err := engine.LookupEntity(IdentityLookup{AliasName: "identity-login", AliasMountAccessor: mountAccessor})
+		assert.Nil(t, err)
+		assert.Equal(t, entity.Id, byAlias.Id)
+	})
+
+	t.Run("merged entity should own aliases of source entity", func(t *testing.T) {
+		target, err := engine.CreateEntity(IdentityEntity{Name: "identity-merge-target"})
+		assert.Nil(t, err)
+
+		err = engine.MergeEntities(target.Id, []string{entity.Id}, false)
+		assert.Nil(t, err)
+
+		merged, err := engine.ReadEntityAlias(alias.Id)
+		assert.Nil(t, err)
+		assert.Equal(t, target.Id, merged.CanonicalId)
+
+		source, err := engine.ReadEntity(entity.Id)
+		assert.NotNil(t, err)
+		assert.Nil(t, source)
+
+		entity = target
+	})
+
+	var group *IdentityGroup
+	t.Run("internal group should contain member entity", func(t *testing.T) {
+		group, err = engine.CreateGroup(IdentityGroup{
+			Name:            "identity-internal",
+			Type:            InternalGroup,
+			Policies:        []string{"default"},
+			MemberEntityIds: []string{entity.Id},
+		})
+		assert.Nil(t, err)
+		assert.NotNil(t, group)
+		assert.Equal(t, []string{entity.Id}, group.MemberEntityIds)
+
+		byName, err := engine.ReadGroupByName(group.Name)
+		assert.Nil(t, err)
+		assert.Equal(t, group.Id, byName.Id)
+
+		member, err := engine.ReadEntity(entity.Id)
+		assert.Nil(t, err)
+		assert.Contains(t, member.DirectGroupIds, group.Id)
+	})
+
+	t.Run("external group should be found by group alias", func(t *testing.T) {
+		external, err := engine.CreateGroup(IdentityGroup{Name: "identity-external", Type: ExternalGroup})
+		assert.Nil(t, err)
+
+		groupAlias, err := engine.CreateGroupAlias(IdentityGroupAlias{Name: "ldap-admins", CanonicalId: external.Id, MountAccessor: mountAccessor})
+		assert.Nil(t, err)
+		assert.NotNil(t, groupAlias)
+
+		found, err := engine.LookupGroup(IdentityLookup{AliasId: groupAlias.Id})
+		assert.Nil(t, err)
+		assert.Equal(t, external.Id, found.Id)
+		assert.Equal(t, groupAlias.Id, found.Alias.Id)
+
+		list, err := engine.ListGroupAlias()
+		assert.Nil(t, err)
+		assert.Contains(t, list, groupAlias.Id)
+
+		err = engine.DeleteGroupAlias(groupAlias.Id)
+		assert.Nil(t, err)
+		err = engine.DeleteGroup(external.Id)
+		assert.Nil(t, err)
+	})
+
+	t.Run("cannot fetch deleted group and entity", func(t *testing.T) {
+		err := engine.DeleteGroup(group.Id)
+		assert.Nil(t, err)
+		deletedGroup, err := engine.ReadGroup(group.Id)
+		assert.NotNil(t, err)
+		assert.Nil(t, deletedGroup)
+
+		err = engine.DeleteEntityAlias(alias.Id)
+		assert.Nil(t, err)
+		err = engine.DeleteEntity(entity.Id)
+		assert.Nil(t, err)
+		deletedEntity, err := engine.ReadEntity(entity.Id)
+		assert.NotNil(t, err)
+		assert.Nil(t, deletedEntity)
+	})
+
+}
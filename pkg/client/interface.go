@@ -1,5 +1,13 @@
 package client
 
+import (
+	"context"
+	"golang.org/x/crypto/ssh"
+	"gopkg.in/go-jose/go-jose.v2"
+	"io"
+	"time"
+)
+
 type Database interface {
 	Path() string
 	Enable() error
@@ -16,17 +24,36 @@ type Database interface {
 	DeleteRole(name string) error
 	ListRole() ([]string, error)
 
+	CreateStaticRole(name string, role DatabaseStaticRole) error
+	ReadStaticRole(name string) (*DatabaseStaticRole, error)
+	DeleteStaticRole(name string) error
+	ListStaticRole() ([]string, error)
+	ReadStaticCreds(roleName string) (*StaticCreds, error)
+	RotateStaticRole(name string) error
+	RotateRoot(connectionName string) error
+
 	GenerateCreds(roleName string) (*Creds, error)
+	GenerateCredsWrapped(roleName string, options WrapOptions) (*WrapInfo, error)
+	UnwrapCreds(token string) (*Creds, error)
 	ListLease(roleName string) ([]string, error)
 }
 
 type Lease interface {
 	Lookup(leaseId string) (*LeaseDetail, error)
 	List(prefix string) ([]string, error)
+	ListAll(prefix string) ([]string, error)
+	Inventory(prefix string, options LeaseReportOptions) (*LeaseReport, error)
 	Renew(leaseId string, increment int) error
 	Revoke(leaseId string) error
 	RevokePrefix(prefix string) error
+	RevokeWithOptions(leaseId string, options RevokeOptions) (*RevokeResult, error)
+	RevokePrefixWithOptions(prefix string, options RevokeOptions) (*RevokeResult, error)
+	RevokeForce(prefix string, confirm string) (*RevokeResult, error)
 	Tidy() error
+
+	LookupAll(leaseIds []string) []LeaseResult
+	RenewAll(leaseIds []string, increment int) []LeaseResult
+	RevokeAll(leaseIds []string) []LeaseResult
 }
 
 type KV interface {
@@ -40,8 +67,13 @@ type KV interface {
 	Write(path string, input interface{}) (*KVMetadata, error)
 	Read(path string, output interface{}) (*KVMetadata, error)
 	ReadVersion(path string, version int, result interface{}) (*KVMetadata, error)
+	ReadWrapped(path string, options WrapOptions) (*WrapInfo, error)
+	Unwrap(token string, output interface{}) (*KVMetadata, error)
 
 	ReadMetadata(path string) (*KVHistoryMetadata, error)
+	History(path string) ([]KVMetadata, error)
+	Diff(path string, fromVersion int, toVersion int, options KVDiffOptions) ([]KVFieldDiff, error)
+	Rollback(path string, version int) (*KVMetadata, error)
 
 	Delete(path string) error
 	DeleteVersions(path string, versions []int) error
@@ -49,7 +81,234 @@ type KV interface {
 	DestroyVersions(path string, versions []int) error
 
 	List(path string) ([]string, error)
+	ListAll(path string) ([]string, error)
 
 	UpdateMetadata(path string, config KVConfig) error
+	WriteMetadata(path string, metadata KVSecretMetadata) error
 	DestroyAll(path string) error
+
+	ListRotationOverdue(path string, now time.Time) ([]KVRotationStatus, error)
+	Scan(path string, options KVScanOptions) (*KVScanReport, error)
+}
+
+type Wrapping interface {
+	Wrap(input interface{}, options WrapOptions) (*WrapInfo, error)
+	Unwrap(token string, output interface{}) error
+	WrapLookup(token string) (*WrapInfo, error)
+	Rewrap(token string) (*WrapInfo, error)
+}
+
+type SSH interface {
+	Path() string
+	Enable() error
+	Status() (*SecretStatus, error)
+
+	ConfigureCA(config SSHCAConfig) (string, error)
+	ReadCAPublicKey() (string, error)
+	DeleteCA() error
+
+	CreateRole(name string, role SSHRole) error
+	ReadRole(name string) (*SSHRole, error)
+	DeleteRole(name string) error
+	ListRole() ([]string, error)
+
+	SignPublicKey(roleName string, publicKey string, principals []string, ttl int) (*ssh.Certificate, error)
+	GenerateOTP(roleName string, ip string, username string) (*SSHOTPCreds, error)
+	VerifyOTP(otp string) (*SSHOTPVerification, error)
+}
+
+type TOTP interface {
+	Path() string
+	Enable() error
+	Status() (*SecretStatus, error)
+
+	CreateKey(name string, config TOTPKeyConfig) (*TOTPKey, error)
+	ImportKey(name string, otpauthUrl string) error
+	ReadKey(name string) (*TOTPKeyConfig, error)
+	DeleteKey(name string) error
+	ListKey() ([]string, error)
+
+	GenerateCode(name string) (string, error)
+	ValidateCode(name string, code string) (bool, error)
+}
+
+type Cubbyhole interface {
+	Path() string
+
+	Write(path string, input interface{}) error
+	Read(path string, output interface{}) error
+	List(path string) ([]string, error)
+	Delete(path string) error
+}
+
+type Identity interface {
+	CreateEntity(entity IdentityEntity) (*IdentityEntity, error)
+	ReadEntity(id string) (*IdentityEntity, error)
+	ReadEntityByName(name string) (*IdentityEntity, error)
+	UpdateEntity(id string, entity IdentityEntity) error
+	DeleteEntity(id string) error
+	ListEntity() ([]string, error)
+	ListEntityName() ([]string, error)
+	MergeEntities(toEntityId string, fromEntityIds []string, force bool) error
+
+	CreateEntityAlias(alias IdentityEntityAlias) (*IdentityEntityAlias, error)
+	ReadEntityAlias(id string) (*IdentityEntityAlias, error)
+	UpdateEntityAlias(id string, alias IdentityEntityAlias) error
+	DeleteEntityAlias(id string) error
+	ListEntityAlias() ([]string, error)
+
+	CreateGroup(group IdentityGroup) (*IdentityGroup, error)
+	ReadGroup(id string) (*IdentityGroup, error)
+	ReadGroupByName(name string) (*IdentityGroup, error)
+	UpdateGroup(id string, group IdentityGroup) error
+	DeleteGroup(id string) error
+	ListGroup() ([]string, error)
+	ListGroupName() ([]string, error)
+
+	CreateGroupAlias(alias IdentityGroupAlias) (*IdentityGroupAlias, error)
+	ReadGroupAlias(id string) (*IdentityGroupAlias, error)
+	UpdateGroupAlias(id string, alias IdentityGroupAlias) error
+	DeleteGroupAlias(id string) error
+	ListGroupAlias() ([]string, error)
+
+	LookupEntity(lookup IdentityLookup) (*IdentityEntity, error)
+	LookupGroup(lookup IdentityLookup) (*IdentityGroup, error)
+
+	ConfigureOIDC(config IdentityOIDCConfig) error
+	ReadOIDCConfig() (*IdentityOIDCConfig, error)
+
+	CreateOIDCKey(name string, key IdentityOIDCKey) error
+	ReadOIDCKey(name string) (*IdentityOIDCKey, error)
+	DeleteOIDCKey(name string) error
+	ListOIDCKey() ([]string, error)
+	RotateOIDCKey(name string, verificationTtl int) error
+
+	CreateOIDCRole(name string, role IdentityOIDCRole) error
+	ReadOIDCRole(name string) (*IdentityOIDCRole, error)
+	DeleteOIDCRole(name string) error
+	ListOIDCRole() ([]string, error)
+
+	GenerateOIDCToken(roleName string) (*IdentityToken, error)
+	IntrospectOIDCToken(token string, clientId string) (bool, error)
+	ReadOIDCPublicKeys() (*jose.JSONWebKeySet, error)
+}
+
+type Auth interface {
+	ListAuth() (map[string]AuthMount, error)
+	EnableAuth(path string, mount AuthMount) error
+	DisableAuth(path string) error
+	ReadAuthTune(path string) (*AuthMountConfig, error)
+	TuneAuth(path string, config AuthMountConfig) error
+}
+
+type AppRole interface {
+	Path() string
+	Enable() error
+
+	CreateRole(name string, role AppRoleRole) error
+	ReadRole(name string) (*AppRoleRole, error)
+	DeleteRole(name string) error
+	ListRole() ([]string, error)
+	ReadRoleId(name string) (string, error)
+
+	GenerateSecretId(roleName string, options AppRoleSecretIdOptions) (*AppRoleSecretId, error)
+	ListSecretIdAccessor(roleName string) ([]string, error)
+	ReadSecretIdAccessor(roleName string, accessor string) (*AppRoleSecretIdInfo, error)
+	DestroySecretId(roleName string, secretId string) error
+	DestroySecretIdAccessor(roleName string, accessor string) error
+}
+
+type Kubernetes interface {
+	Path() string
+	Enable() error
+
+	Configure(config KubernetesAuthConfig) error
+	ReadConfig() (*KubernetesAuthConfig, error)
+
+	CreateRole(name string, role KubernetesRole) error
+	ReadRole(name string) (*KubernetesRole, error)
+	DeleteRole(name string) error
+	ListRole() ([]string, error)
+}
+
+type JWT interface {
+	Path() string
+	Enable() error
+
+	Configure(config JWTAuthConfig) error
+	ReadConfig() (*JWTAuthConfig, error)
+
+	CreateRole(name string, role JWTRole) error
+	ReadRole(name string) (*JWTRole, error)
+	DeleteRole(name string) error
+	ListRole() ([]string, error)
+}
+
+type Token interface {
+	Create(options TokenCreateOptions) (*TokenAuth, error)
+
+	Lookup(token string) (*TokenInfo, error)
+	LookupSelf() (*TokenInfo, error)
+	LookupAccessor(accessor string) (*TokenInfo, error)
+
+	Renew(token string, increment int) (*TokenAuth, error)
+	RenewSelf(increment int) (*TokenAuth, error)
+
+	Revoke(token string) error
+	RevokeAccessor(accessor string) error
+	RevokeOrphan(token string) error
+
+	CreateRole(name string, role TokenRole) error
+	ReadRole(name string) (*TokenRole, error)
+	DeleteRole(name string) error
+	ListRole() ([]string, error)
+
+	ListAccessor() ([]string, error)
+}
+
+type System interface {
+	Health() (*HealthStatus, error)
+	SealStatus() (*SealStatus, error)
+	Leader() (*LeaderStatus, error)
+	HAStatus() (*HAStatus, error)
+	VersionHistory() ([]VersionHistory, error)
+
+	WaitUntilReady(ctx context.Context, interval time.Duration) error
+}
+
+type Audit interface {
+	List() (map[string]AuditDevice, error)
+	Enable(path string, device AuditDevice) error
+	Disable(path string) error
+	Hash(path string, input string) (string, error)
+}
+
+type Operator interface {
+	InitStatus() (bool, error)
+	Init(options InitOptions) (*InitResult, error)
+	Seal() error
+
+	Unseal() (*UnsealProcess, error)
+	Rekey(options RekeyOptions) (*RekeyProcess, error)
+	GenerateRoot(pgpKey string) (*GenerateRootProcess, error)
+}
+
+type Raft interface {
+	Snapshot(ctx context.Context, writer io.Writer) error
+	Restore(ctx context.Context, reader io.Reader, force bool) error
+
+	Peers() ([]RaftPeer, error)
+	RemovePeer(nodeId string) error
+
+	AutopilotState() (*AutopilotState, error)
+	AutopilotConfig() (*AutopilotConfig, error)
+	ConfigureAutopilot(config AutopilotConfig) error
+}
+
+type PasswordPolicies interface {
+	Create(name string, policy PasswordPolicy) error
+	Read(name string) (*PasswordPolicy, error)
+	Delete(name string) error
+	List() ([]string, error)
+	Generate(name string) (string, error)
 }
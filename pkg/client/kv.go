@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"github.com/hashicorp/vault/api"
 	"github.com/jasoet/vault-client/pkg/util"
+	"strings"
 )
 
 type kvEngine struct {
@@ -34,7 +35,12 @@ func (k kvEngine) Status() (status *SecretStatus, err error) {
 }
 
 func (k kvEngine) WriteConfig(config KVConfig) (err error) {
-	_, err = k.vaultClient.Logical().Write(fmt.Sprintf("%v/config", k.path), util.StructToMap(config))
+	payload, err := util.StructToMapE(config)
+	if err != nil {
+		return
+	}
+
+	_, err = k.vaultClient.Logical().Write(fmt.Sprintf("%v/config", k.path), payload)
 	return
 }
 
@@ -55,8 +61,13 @@ func (k kvEngine) ReadConfig() (config *KVConfig, err error) {
 }
 
 func (k kvEngine) Write(path string, input interface{}) (metadata *KVMetadata, err error) {
+	data, err := util.StructToMapE(input)
+	if err != nil {
+		return
+	}
+
 	payload := map[string]interface{}{
-		"data": util.StructToMap(input),
+		"data": data,
 	}
 
 	result, err := k.vaultClient.Logical().Write(fmt.Sprintf("%v/data/%v", k.path, path), payload)
@@ -120,6 +131,49 @@ func (k kvEngine) ReadVersion(path string, version int, output interface{}) (met
 	return
 }
 
+func (k kvEngine) ReadWrapped(path string, options WrapOptions) (info *WrapInfo, err error) {
+	vaultClient, err := wrappedClient(k.vaultClient, options)
+	if err != nil {
+		return
+	}
+
+	result, err := vaultClient.Logical().Read(fmt.Sprintf("%v/data/%v", k.path, path))
+	if err != nil {
+		return
+	}
+
+	if result == nil {
+		err = fmt.Errorf("%v is not found", path)
+		return
+	}
+
+	return toWrapInfo(result)
+}
+
+func (k kvEngine) Unwrap(token string, output interface{}) (metadata *KVMetadata, err error) {
+	secret, err := k.vaultClient.Logical().Unwrap(token)
+	if err != nil || secret == nil {
+		return
+	}
+
+	if val, ok := secret.Data["data"]; ok {
+		err = util.MapToStruct(val, output)
+		if err != nil {
+			return
+		}
+	}
+
+	if val, ok := secret.Data["metadata"]; ok {
+		metadata = new(KVMetadata)
+		err = util.MapToStruct(val, metadata)
+		if err != nil {
+			return
+		}
+	}
+
+	return
+}
+
 func (k kvEngine) ReadMetadata(path string) (metadata *KVHistoryMetadata, err error) {
 	secret, err := k.vaultClient.Logical().Read(fmt.Sprintf("%v/metadata/%v", k.path, path))
 	if err != nil || secret == nil {
@@ -173,8 +227,56 @@ func (k kvEngine) List(path string) (list []string, err error) {
 	return
 }
 
+// ListAll list secret paths under path recursively, folders are not included
+func (k kvEngine) ListAll(path string) (list []string, err error) {
+	list = []string{}
+	if path != "" && !strings.HasSuffix(path, "/") {
+		path = fmt.Sprintf("%v/", path)
+	}
+
+	keys, err := k.List(path)
+	if err != nil {
+		return
+	}
+
+	for _, key := range keys {
+		key = path + key
+		if !strings.HasSuffix(key, "/") {
+			list = append(list, key)
+			continue
+		}
+
+		children, err := k.ListAll(key)
+		if err != nil {
+			return nil, err
+		}
+		list = append(list, children...)
+	}
+
+	return
+}
+
 func (k kvEngine) UpdateMetadata(path string, config KVConfig) (err error) {
-	_, err = k.vaultClient.Logical().Write(fmt.Sprintf("%v/metadata/%v", k.path, path), util.StructToMap(config))
+	payload, err := util.StructToMapE(config)
+	if err != nil {
+		return
+	}
+
+	_, err = k.vaultClient.Logical().Write(fmt.Sprintf("%v/metadata/%v", k.path, path), payload)
+	return
+}
+
+func (k kvEngine) WriteMetadata(path string, metadata KVSecretMetadata) (err error) {
+	payload, err := util.StructToMapE(metadata)
+	if err != nil {
+		return
+	}
+
+	if metadata.ClearCustomMetadata && len(metadata.CustomMetadata) == 0 {
+		payload["custom_metadata"] = map[string]string{}
+	}
+
+	_, err = k.vaultClient.Logical().Write(fmt.Sprintf("%v/metadata/%v", k.path, path), payload)
 	return
 }
 
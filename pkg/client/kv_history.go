@@ -0,0 +1,162 @@
+package client
+
+import (
+	"fmt"
+	"github.com/jasoet/vault-client/pkg/util"
+	"reflect"
+	"sort"
+	"strconv"
+)
+
+// KVMaskedValue replace secret values in KVFieldDiff unless KVDiffOptions.ShowValues is set
+const KVMaskedValue = "********"
+
+type KVDiffType string
+
+const (
+	KVFieldAdded   KVDiffType = "added"
+	KVFieldRemoved KVDiffType = "removed"
+	KVFieldChanged KVDiffType = "changed"
+)
+
+type KVDiffOptions struct {
+	ShowValues bool `json:"show_values"`
+}
+
+// KVFieldDiff OldValue is empty for added field and NewValue is empty for removed field
+type KVFieldDiff struct {
+	Field    string      `json:"field"`
+	Type     KVDiffType  `json:"type"`
+	OldValue interface{} `json:"old_value,omitempty"`
+	NewValue interface{} `json:"new_value,omitempty"`
+}
+
+// History return metadata of every version ordered by version, oldest first
+func (k kvEngine) History(path string) (history []KVMetadata, err error) {
+	metadata, err := k.ReadMetadata(path)
+	if err != nil {
+		return
+	}
+
+	if metadata == nil {
+		err = fmt.Errorf("%v is not found", path)
+		return
+	}
+
+	history = []KVMetadata{}
+	for key, version := range metadata.Versions {
+		version.Version, err = strconv.Atoi(key)
+		if err != nil {
+			return nil, err
+		}
+		history = append(history, version)
+	}
+
+	sort.Slice(history, func(i, j int) bool {
+		return history[i].Version < history[j].Version
+	})
+	return
+}
+
+// Diff compare top level fields of fromVersion and toVersion
+func (k kvEngine) Diff(path string, fromVersion int, toVersion int, options KVDiffOptions) (diff []KVFieldDiff, err error) {
+	from, err := k.readVersionData(path, fromVersion)
+	if err != nil {
+		return
+	}
+
+	to, err := k.readVersionData(path, toVersion)
+	if err != nil {
+		return
+	}
+
+	return DiffKVData(from, to, options), nil
+}
+
+// Rollback write data of version as the new current version, the write fail when another version is
+// written in between because it use check-and-set against the current version
+func (k kvEngine) Rollback(path string, version int) (metadata *KVMetadata, err error) {
+	data, err := k.readVersionData(path, version)
+	if err != nil {
+		return
+	}
+
+	history, err := k.ReadMetadata(path)
+	if err != nil {
+		return
+	}
+
+	if history == nil {
+		err = fmt.Errorf("%v is not found", path)
+		return
+	}
+
+	if version == history.CurrentVersion {
+		err = fmt.Errorf("version %v of %v is already the current version", version, path)
+		return
+	}
+
+	payload := map[string]interface{}{
+		"options": map[string]interface{}{"cas": history.CurrentVersion},
+		"data":    data,
+	}
+	result, err := k.vaultClient.Logical().Write(fmt.Sprintf("%v/data/%v", k.path, path), payload)
+	if err != nil {
+		return
+	}
+
+	metadata = new(KVMetadata)
+	err = util.MapToStruct(result.Data, metadata)
+	return
+}
+
+func (k kvEngine) readVersionData(path string, version int) (data map[string]interface{}, err error) {
+	data = map[string]interface{}{}
+	metadata, err := k.ReadVersion(path, version, &data)
+	if err != nil {
+		return
+	}
+
+	if metadata == nil {
+		err = fmt.Errorf("version %v of %v is not found", version, path)
+		return
+	}
+
+	if metadata.Destroyed || metadata.DeletionTime != nil {
+		err = fmt.Errorf("version %v of %v is deleted", version, path)
+		return
+	}
+	return
+}
+
+// DiffKVData compare top level fields of from and to, ordered by field name. Values are masked unless options.ShowValues
+func DiffKVData(from map[string]interface{}, to map[string]interface{}, options KVDiffOptions) (diff []KVFieldDiff) {
+	value := func(val interface{}) interface{} {
+		if options.ShowValues {
+			return val
+		}
+		return KVMaskedValue
+	}
+
+	diff = []KVFieldDiff{}
+	for field, oldValue := range from {
+		newValue, ok := to[field]
+		switch {
+		case !ok:
+			diff = append(diff, KVFieldDiff{Field: field, Type: KVFieldRemoved, OldValue: value(oldValue)})
+		case !reflect.DeepEqual(oldValue, newValue):
+			diff = append(diff, KVFieldDiff{Field: field, Type: KVFieldChanged, OldValue: value(oldValue), NewValue: value(newValue)})
+		}
+	}
+
+	for field, newValue := range to {
+		if _, ok := from[field]; !ok {
+			diff = append(diff, KVFieldDiff{Field: field, Type: KVFieldAdded, NewValue: value(newValue)})
+		}
+	}
+
+	sort.Slice(diff, func(i, j int) bool {
+		return diff[i].Field < diff[j].Field
+	})
+	return
+}
@@ -0,0 +1,47 @@
+package client_test
+
+import (
+	. "github.com/jasoet/vault-client/pkg/client"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestDiffKVData(t *testing.T) {
+	from := map[string]interface{}{
+		"username": "root",
+		"password": "first",
+		"host":     "db",
+		"roles":    []interface{}{"read"},
+	}
+	to := map[string]interface{}{
+		"username": "root",
+		"password": "second",
+		"port":     "3306",
+		"roles":    []interface{}{"read", "write"},
+	}
+
+	t.Run("should mask values by default", func(t *testing.T) {
+		diff := DiffKVData(from, to, KVDiffOptions{})
+		assert.Equal(t, []KVFieldDiff{
+			{Field: "host", Type: KVFieldRemoved, OldValue: KVMaskedValue},
+			{Field: "password", Type: KVFieldChanged, OldValue: KVMaskedValue, NewValue: KVMaskedValue},
+			{Field: "port", Type: KVFieldAdded, NewValue: KVMaskedValue},
+			{Field: "roles", Type: KVFieldChanged, OldValue: KVMaskedValue, NewValue: KVMaskedValue},
+		}, diff)
+	})
+
+	t.Run("should show values when requested", func(t *testing.T) {
+		diff := DiffKVData(from, to, KVDiffOptions{ShowValues: true})
+		assert.Equal(t, []KVFieldDiff{
+			{Field: "host", Type: KVFieldRemoved, OldValue: "db"},
+			{Field: "password", Type: KVFieldChanged, OldValue: "first", NewValue: "second"},
+			{Field: "port", Type: KVFieldAdded, NewValue: "3306"},
+			{Field: "roles", Type: KVFieldChanged, OldValue: []interface{}{"read"}, NewValue: []interface{}{"read", "write"}},
+		}, diff)
+	})
+
+	t.Run("should return empty diff for identical data", func(t *testing.T) {
+		assert.Empty(t, DiffKVData(from, from, KVDiffOptions{}))
+		assert.Empty(t, DiffKVData(nil, nil, KVDiffOptions{}))
+	})
+}
@@ -0,0 +1,64 @@
+package client
+
+import (
+	"fmt"
+	"time"
+)
+
+// KVRotateAfterKey is the custom metadata key holding the time a secret must be rotated,
+// in RFC3339 (`2021-06-30T00:00:00Z`) or date (`2021-06-30`, UTC midnight) format
+const KVRotateAfterKey = "rotate-after"
+
+// KVRotationStatus Err is set when the metadata can not be read or rotate-after can not be parsed
+type KVRotationStatus struct {
+	Path           string            `json:"path"`
+	RotateAfter    *time.Time        `json:"rotate_after"`
+	CustomMetadata map[string]string `json:"custom_metadata"`
+	Err            error             `json:"-"`
+}
+
+// RotateAfter parse rotate-after custom metadata, nil when the secret has no rotate-after
+func RotateAfter(metadata KVHistoryMetadata) (rotateAfter *time.Time, err error) {
+	value, ok := metadata.CustomMetadata[KVRotateAfterKey]
+	if !ok || value == "" {
+		return
+	}
+
+	for _, layout := range []string{time.RFC3339, "2006-01-02"} {
+		parsed, parseErr := time.Parse(layout, value)
+		if parseErr == nil {
+			return &parsed, nil
+		}
+	}
+
+	err = fmt.Errorf("%v `%v` is not RFC3339 time or date", KVRotateAfterKey, value)
+	return
+}
+
+// ListRotationOverdue list secrets under path recursively whose rotate-after is not after now.
+// Secrets with unreadable metadata or invalid rotate-after are included with Err set so they are not missed
+func (k kvEngine) ListRotationOverdue(path string, now time.Time) (overdue []KVRotationStatus, err error) {
+	paths, err := k.ListAll(path)
+	if err != nil {
+		return
+	}
+
+	overdue = []KVRotationStatus{}
+	for _, secretPath := range paths {
+		metadata, err := k.ReadMetadata(secretPath)
+		if err != nil {
+			overdue = append(overdue, KVRotationStatus{Path: secretPath, Err: err})
+			continue
+		}
+		if metadata == nil {
+			continue
+		}
+
+		rotateAfter, err := RotateAfter(*metadata)
+		status := KVRotationStatus{Path: secretPath, RotateAfter: rotateAfter, CustomMetadata: metadata.CustomMetadata, Err: err}
+		if err != nil || (rotateAfter != nil && !rotateAfter.After(now)) {
+			overdue = append(overdue, status)
+		}
+	}
+	return
+}
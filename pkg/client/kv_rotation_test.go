@@ -0,0 +1,41 @@
+package client_test
+
+import (
+	. "github.com/jasoet/vault-client/pkg/client"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+func TestRotateAfter(t *testing.T) {
+	metadata := func(value string) KVHistoryMetadata {
+		return KVHistoryMetadata{CustomMetadata: map[string]string{"owner": "platform", KVRotateAfterKey: value}}
+	}
+
+	t.Run("should parse RFC3339 and date", func(t *testing.T) {
+		rotateAfter, err := RotateAfter(metadata("2021-06-30T10:00:00+07:00"))
+		require.NoError(t, err)
+		assert.True(t, time.Date(2021, 6, 30, 3, 0, 0, 0, time.UTC).Equal(*rotateAfter))
+
+		rotateAfter, err = RotateAfter(metadata("2021-06-30"))
+		require.NoError(t, err)
+		assert.True(t, time.Date(2021, 6, 30, 0, 0, 0, 0, time.UTC).Equal(*rotateAfter))
+	})
+
+	t.Run("should return nil without rotate-after", func(t *testing.T) {
+		rotateAfter, err := RotateAfter(KVHistoryMetadata{CustomMetadata: map[string]string{"owner": "platform"}})
+		assert.NoError(t, err)
+		assert.Nil(t, rotateAfter)
+
+		rotateAfter, err = RotateAfter(KVHistoryMetadata{})
+		assert.NoError(t, err)
+		assert.Nil(t, rotateAfter)
+	})
+
+	t.Run("should reject invalid rotate-after", func(t *testing.T) {
+		rotateAfter, err := RotateAfter(metadata("next month"))
+		assert.EqualError(t, err, "rotate-after `next month` is not RFC3339 time or date")
+		assert.Nil(t, rotateAfter)
+	})
+}
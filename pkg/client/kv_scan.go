@@ -0,0 +1,354 @@
+package client
+
+import (
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	defaultMinEntropy = 40
+	defaultStaleDays  = 90
+
+	// shorter values such as `admin` or `true` are expected to repeat and are not reported as duplicate
+	minDuplicateLength = 8
+)
+
+var defaultPasswordFields = []string{"password", "passwd", "pwd", "passphrase", "secret"}
+
+type KVFindingType string
+
+const (
+	KVWeakPassword   KVFindingType = "weak-password"
+	KVDuplicateValue KVFindingType = "duplicate-value"
+	KVMisplacedKey   KVFindingType = "misplaced-key"
+	KVStaleSecret    KVFindingType = "stale-secret"
+)
+
+// kvKeyPattern detect key material by value, it is misplaced when the field name contains none of fields
+type kvKeyPattern struct {
+	name    string
+	pattern *regexp.Regexp
+	fields  []string
+}
+
+var kvKeyPatterns = []kvKeyPattern{
+	{name: "private key", pattern: regexp.MustCompile(`-----BEGIN [A-Z ]*PRIVATE KEY( BLOCK)?-----`), fields: []string{"key", "pem", "cert"}},
+	{name: "vault token", pattern: regexp.MustCompile(`^(hvs|hvb|s|b)\.[A-Za-z0-9]{24,}$`), fields: []string{"token"}},
+	{name: "aws access key", pattern: regexp.MustCompile(`^(AKIA|ASIA)[0-9A-Z]{16}$`), fields: []string{"key", "access"}},
+	{name: "github token", pattern: regexp.MustCompile(`^(ghp|gho|ghu|ghs|ghr|github_pat)_[A-Za-z0-9_]{20,}$`), fields: []string{"token"}},
+	{name: "slack token", pattern: regexp.MustCompile(`^xox[abposr]-[A-Za-z0-9-]{10,}$`), fields: []string{"token"}},
+	{name: "jwt", pattern: regexp.MustCompile(`^eyJ[A-Za-z0-9_-]+\.eyJ[A-Za-z0-9_-]+\.[A-Za-z0-9_-]*$`), fields: []string{"token", "jwt"}},
+}
+
+// KVScanOptions configure NewKVScanReport.
+// MinEntropy is the minimum shannon entropy in bits of the whole value (default 40) for fields whose name contains
+// one of PasswordFields (default password, passwd, pwd, passphrase and secret).
+// Secrets whose current version is older than StaleDays (default 90) are reported as stale.
+type KVScanOptions struct {
+	MinEntropy     float64  `json:"min_entropy"`
+	PasswordFields []string `json:"password_fields"`
+	StaleDays      int      `json:"stale_days"`
+}
+
+// KVFinding never contain secret value, Field is dot separated for nested value,
+// RelatedPaths is the other `path#field` holding the same value for duplicate-value
+type KVFinding struct {
+	Type         KVFindingType `json:"type"`
+	Path         string        `json:"path"`
+	Field        string        `json:"field,omitempty"`
+	Message      string        `json:"message"`
+	RelatedPaths []string      `json:"related_paths,omitempty"`
+}
+
+// KVScanSecret is the input of NewKVScanReport, Data is the current version of the secret
+type KVScanSecret struct {
+	Path     string
+	Data     map[string]interface{}
+	Metadata KVHistoryMetadata
+}
+
+type KVScanReport struct {
+	GeneratedTime time.Time   `json:"generated_time"`
+	Scanned       int         `json:"scanned"`
+	Findings      []KVFinding `json:"findings"`
+	Failed        []string    `json:"failed"`
+}
+
+// Scan read current version of every secret under path recursively and report weak, duplicated,
+// misplaced and stale secrets. Secrets that can not be read are listed in KVScanReport.Failed
+func (k kvEngine) Scan(path string, options KVScanOptions) (report *KVScanReport, err error) {
+	paths, err := k.ListAll(path)
+	if err != nil {
+		return
+	}
+
+	var secrets []KVScanSecret
+	var failed []string
+	for _, secretPath := range paths {
+		metadata, err := k.ReadMetadata(secretPath)
+		if err != nil || metadata == nil {
+			failed = append(failed, secretPath)
+			continue
+		}
+
+		if isCurrentVersionDeleted(*metadata) {
+			continue
+		}
+
+		data := map[string]interface{}{}
+		_, err = k.ReadVersion(secretPath, metadata.CurrentVersion, &data)
+		if err != nil {
+			failed = append(failed, secretPath)
+			continue
+		}
+		secrets = append(secrets, KVScanSecret{Path: secretPath, Data: data, Metadata: *metadata})
+	}
+
+	report = NewKVScanReport(secrets, options, time.Now())
+	report.Failed = append(report.Failed, failed...)
+	return
+}
+
+// NewKVScanReport scan secrets, findings are ordered by path, field and type
+func NewKVScanReport(secrets []KVScanSecret, options KVScanOptions, now time.Time) *KVScanReport {
+	minEntropy := options.MinEntropy
+	if minEntropy <= 0 {
+		minEntropy = defaultMinEntropy
+	}
+
+	passwordFields := options.PasswordFields
+	if len(passwordFields) == 0 {
+		passwordFields = defaultPasswordFields
+	}
+
+	staleDays := options.StaleDays
+	if staleDays <= 0 {
+		staleDays = defaultStaleDays
+	}
+
+	report := &KVScanReport{GeneratedTime: now, Scanned: len(secrets), Findings: []KVFinding{}, Failed: []string{}}
+
+	// only the hash of a value is kept to find duplicates
+	locations := map[[sha256.Size]byte][]KVFinding{}
+	for _, secret := range secrets {
+		for field, value := range flattenKVData("", secret.Data) {
+			if value == "" {
+				continue
+			}
+
+			if len(value) >= minDuplicateLength {
+				hash := sha256.Sum256([]byte(value))
+				locations[hash] = append(locations[hash], KVFinding{Type: KVDuplicateValue, Path: secret.Path, Field: field})
+			}
+
+			if containsAny(field, passwordFields) {
+				if entropy := shannonEntropy(value); entropy < minEntropy {
+					report.Findings = append(report.Findings, KVFinding{
+						Type:    KVWeakPassword,
+						Path:    secret.Path,
+						Field:   field,
+						Message: fmt.Sprintf("password entropy is %.1f bits, less than %v bits", entropy, minEntropy),
+					})
+				}
+			}
+
+			for _, keyPattern := range kvKeyPatterns {
+				if keyPattern.pattern.MatchString(value) && !containsAny(field, keyPattern.fields) {
+					report.Findings = append(report.Findings, KVFinding{
+						Type:    KVMisplacedKey,
+						Path:    secret.Path,
+						Field:   field,
+						Message: fmt.Sprintf("value looks like %v but field name does not contain %v", keyPattern.name, strings.Join(keyPattern.fields, ", ")),
+					})
+				}
+			}
+		}
+
+		updatedTime := secret.Metadata.CreatedTime
+		if version, ok := secret.Metadata.Versions[strconv.Itoa(secret.Metadata.CurrentVersion)]; ok {
+			updatedTime = version.CreatedTime
+		}
+		if age := now.Sub(updatedTime); age > time.Duration(staleDays)*24*time.Hour {
+			report.Findings = append(report.Findings, KVFinding{
+				Type:    KVStaleSecret,
+				Path:    secret.Path,
+				Message: fmt.Sprintf("secret is not updated for %v days", int(age.Hours()/24)),
+			})
+		}
+	}
+
+	for _, duplicates := range locations {
+		if len(duplicates) < 2 {
+			continue
+		}
+
+		for index, finding := range duplicates {
+			finding.RelatedPaths = []string{}
+			for relatedIndex, related := range duplicates {
+				if relatedIndex != index {
+					finding.RelatedPaths = append(finding.RelatedPaths, fmt.Sprintf("%v#%v", related.Path, related.Field))
+				}
+			}
+			sort.Strings(finding.RelatedPaths)
+			finding.Message = fmt.Sprintf("value is also stored in %v other field(s)", len(finding.RelatedPaths))
+			report.Findings = append(report.Findings, finding)
+		}
+	}
+
+	sort.Slice(report.Findings, func(i, j int) bool {
+		a, b := report.Findings[i], report.Findings[j]
+		if a.Path != b.Path {
+			return a.Path < b.Path
+		}
+		if a.Field != b.Field {
+			return a.Field < b.Field
+		}
+		return a.Type < b.Type
+	})
+	return report
+}
+
+func (r KVScanReport) WriteJSON(w io.Writer) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(r)
+}
+
+// WriteSARIF write findings as SARIF 2.1.0 log, one rule per finding type and secret paths as artifact locations
+func (r KVScanReport) WriteSARIF(w io.Writer) error {
+	type message struct {
+		Text string `json:"text"`
+	}
+	type location struct {
+		PhysicalLocation struct {
+			ArtifactLocation struct {
+				Uri string `json:"uri"`
+			} `json:"artifactLocation"`
+		} `json:"physicalLocation"`
+	}
+	type result struct {
+		RuleId    string     `json:"ruleId"`
+		Level     string     `json:"level"`
+		Message   message    `json:"message"`
+		Locations []location `json:"locations"`
+	}
+	type rule struct {
+		Id               string  `json:"id"`
+		ShortDescription message `json:"shortDescription"`
+	}
+
+	rules := []rule{
+		{Id: string(KVWeakPassword), ShortDescription: message{Text: "Password has low entropy"}},
+		{Id: string(KVDuplicateValue), ShortDescription: message{Text: "Secret value is stored in more than one place"}},
+		{Id: string(KVMisplacedKey), ShortDescription: message{Text: "Key material is stored in an unexpected field"}},
+		{Id: string(KVStaleSecret), ShortDescription: message{Text: "Secret is not updated recently"}},
+	}
+
+	results := []result{}
+	for _, finding := range r.Findings {
+		level := "error"
+		if finding.Type == KVStaleSecret {
+			level = "warning"
+		}
+
+		text := finding.Message
+		if finding.Field != "" {
+			text = fmt.Sprintf("%v: %v", finding.Field, finding.Message)
+		}
+		if len(finding.RelatedPaths) > 0 {
+			text = fmt.Sprintf("%v (%v)", text, strings.Join(finding.RelatedPaths, ", "))
+		}
+
+		findingLocation := location{}
+		findingLocation.PhysicalLocation.ArtifactLocation.Uri = finding.Path
+		results = append(results, result{
+			RuleId:    string(finding.Type),
+			Level:     level,
+			Message:   message{Text: text},
+			Locations: []location{findingLocation},
+		})
+	}
+
+	log := map[string]interface{}{
+		"$schema": "https://json.schemastore.org/sarif-2.1.0.json",
+		"version": "2.1.0",
+		"runs": []interface{}{
+			map[string]interface{}{
+				"tool": map[string]interface{}{
+					"driver": map[string]interface{}{
+						"name":           "vault-client",
+						"informationUri": "https://github.com/jasoet/vault-client",
+						"rules":          rules,
+					},
+				},
+				"results": results,
+			},
+		},
+	}
+
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(log)
+}
+
+// flattenKVData return string values keyed by dot separated field, other values are skipped
+func flattenKVData(prefix string, data interface{}) map[string]string {
+	result := map[string]string{}
+	add := func(field string, value interface{}) {
+		for key, nested := range flattenKVData(field, value) {
+			result[key] = nested
+		}
+	}
+
+	switch value := data.(type) {
+	case map[string]interface{}:
+		for key, nested := range value {
+			if prefix != "" {
+				key = fmt.Sprintf("%v.%v", prefix, key)
+			}
+			add(key, nested)
+		}
+	case []interface{}:
+		for index, nested := range value {
+			add(fmt.Sprintf("%v.%v", prefix, index), nested)
+		}
+	case string:
+		result[prefix] = value
+	}
+	return result
+}
+
+// shannonEntropy return entropy of the whole value in bits
+func shannonEntropy(value string) float64 {
+	counts := map[rune]int{}
+	length := 0
+	for _, char := range value {
+		counts[char]++
+		length++
+	}
+
+	entropy := 0.0
+	for _, count := range counts {
+		probability := float64(count) / float64(length)
+		entropy -= probability * math.Log2(probability)
+	}
+	return entropy * float64(length)
+}
+
+func containsAny(field string, names []string) bool {
+	field = strings.ToLower(field)
+	for _, name := range names {
+		if strings.Contains(field, strings.ToLower(name)) {
+			return true
+		}
+	}
+	return false
+}
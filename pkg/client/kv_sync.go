@@ -0,0 +1,332 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// KVSyncVersionKey is the destination custom metadata key holding the source version a secret was copied from,
+// secrets are copied again when the source current version is different
+const KVSyncVersionKey = "sync-source-version"
+
+type KVSyncAction string
+
+const (
+	KVSyncCreate KVSyncAction = "create"
+	KVSyncUpdate KVSyncAction = "update"
+	KVSyncDelete KVSyncAction = "delete"
+)
+
+// KVSyncOptions configure KVSync.
+// Path is the source tree to sync (empty for the whole mount), DestinationPath default to Path.
+// Include and Exclude are `path.Match` globs matched against the secret path relative to Path, e.g. `app/*`,
+// empty Include match every secret and Exclude win over Include.
+// Delete soft delete the current version of destination secrets previously copied by KVSync whose source is deleted,
+// only paths matching Include and Exclude are considered and secrets written directly to the destination are never deleted.
+// Destroy permanently remove every version of those destination secrets instead, it is only used with Delete.
+// DryRun only report the changes.
+type KVSyncOptions struct {
+	Path            string   `json:"path"`
+	DestinationPath string   `json:"destination_path"`
+	Include         []string `json:"include"`
+	Exclude         []string `json:"exclude"`
+	Delete          bool     `json:"delete"`
+	Destroy         bool     `json:"destroy"`
+	DryRun          bool     `json:"dry_run"`
+}
+
+// KVSyncChange Path is relative to KVSyncOptions.Path, Err is set when the change failed
+type KVSyncChange struct {
+	Path          string       `json:"path"`
+	Action        KVSyncAction `json:"action"`
+	SourceVersion int          `json:"source_version,omitempty"`
+	Err           error        `json:"-"`
+}
+
+type KVSyncReport struct {
+	StartedTime time.Time      `json:"started_time"`
+	DryRun      bool           `json:"dry_run"`
+	Changes     []KVSyncChange `json:"changes"`
+	Unchanged   int            `json:"unchanged"`
+}
+
+// Failed return changes with Err set
+func (r KVSyncReport) Failed() (failed []KVSyncChange) {
+	failed = []KVSyncChange{}
+	for _, change := range r.Changes {
+		if change.Err != nil {
+			failed = append(failed, change)
+		}
+	}
+	return
+}
+
+// KVSync copy secrets from source to destination KV, both may be mounted on different paths or clusters
+type KVSync struct {
+	source      KV
+	destination KV
+	options     KVSyncOptions
+}
+
+func NewKVSync(source KV, destination KV, options KVSyncOptions) (sync *KVSync, err error) {
+	for _, pattern := range append(append([]string{}, options.Include...), options.Exclude...) {
+		if _, err = path.Match(pattern, ""); err != nil {
+			err = fmt.Errorf("invalid glob `%v`: %v", pattern, err)
+			return
+		}
+	}
+
+	options.Path = strings.Trim(options.Path, "/")
+	options.DestinationPath = strings.Trim(options.DestinationPath, "/")
+	if options.DestinationPath == "" {
+		options.DestinationPath = options.Path
+	}
+
+	sync = &KVSync{source: source, destination: destination, options: options}
+	return
+}
+
+// Sync compare source and destination once. Listing error abort the sync,
+// error of a single secret is recorded in its KVSyncChange and the sync continue
+func (s *KVSync) Sync() (report *KVSyncReport, err error) {
+	report = &KVSyncReport{StartedTime: time.Now(), DryRun: s.options.DryRun, Changes: []KVSyncChange{}}
+
+	sourcePaths, err := s.list(s.source, s.options.Path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list source: %v", err)
+	}
+
+	destinationPaths, err := s.list(s.destination, s.options.DestinationPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list destination: %v", err)
+	}
+
+	synced := map[string]bool{}
+	for _, secretPath := range sourcePaths {
+		synced[secretPath] = true
+		change, err := s.compare(secretPath)
+		if err == nil && change == nil {
+			report.Unchanged++
+			continue
+		}
+
+		if err != nil {
+			report.Changes = append(report.Changes, KVSyncChange{Path: secretPath, Err: err})
+			continue
+		}
+
+		if !s.options.DryRun {
+			change.Err = s.apply(*change)
+		}
+		report.Changes = append(report.Changes, *change)
+	}
+
+	if !s.options.Delete {
+		return
+	}
+
+	for _, secretPath := range destinationPaths {
+		if synced[secretPath] {
+			continue
+		}
+
+		change, err := s.orphan(secretPath)
+		if err != nil {
+			report.Changes = append(report.Changes, KVSyncChange{Path: secretPath, Action: KVSyncDelete, Err: err})
+			continue
+		}
+		if change == nil {
+			continue
+		}
+
+		if !s.options.DryRun {
+			change.Err = s.apply(*change)
+		}
+		report.Changes = append(report.Changes, *change)
+	}
+	return
+}
+
+// Run call Sync immediately and then every interval until ctx is done, handler receive every report or error
+func (s *KVSync) Run(ctx context.Context, interval time.Duration, handler func(report *KVSyncReport, err error)) error {
+	if interval <= 0 {
+		return fmt.Errorf("interval must be positive: %v", interval)
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		report, err := s.Sync()
+		if handler != nil {
+			handler(report, err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// compare return nil change when the destination is up to date, delete change when the source current version
+// is deleted and the destination was copied by KVSync
+func (s *KVSync) compare(secretPath string) (change *KVSyncChange, err error) {
+	sourceMetadata, err := s.source.ReadMetadata(s.sourcePath(secretPath))
+	if err != nil {
+		return
+	}
+
+	if sourceMetadata == nil || isCurrentVersionDeleted(*sourceMetadata) {
+		if !s.options.Delete {
+			return
+		}
+		return s.orphan(secretPath)
+	}
+
+	destinationMetadata, err := s.destination.ReadMetadata(s.destinationPath(secretPath))
+	if err != nil {
+		return
+	}
+
+	sourceVersion := sourceMetadata.CurrentVersion
+	if destinationMetadata == nil {
+		return &KVSyncChange{Path: secretPath, Action: KVSyncCreate, SourceVersion: sourceVersion}, nil
+	}
+
+	if isCurrentVersionDeleted(*destinationMetadata) ||
+		destinationMetadata.CustomMetadata[KVSyncVersionKey] != strconv.Itoa(sourceVersion) {
+		return &KVSyncChange{Path: secretPath, Action: KVSyncUpdate, SourceVersion: sourceVersion}, nil
+	}
+	return
+}
+
+// orphan return delete change when the destination secret exists and was copied by KVSync,
+// a soft deleted destination is only changed again when Destroy is set
+func (s *KVSync) orphan(secretPath string) (change *KVSyncChange, err error) {
+	metadata, err := s.destination.ReadMetadata(s.destinationPath(secretPath))
+	if err != nil || metadata == nil {
+		return
+	}
+
+	if !s.options.Destroy && isCurrentVersionDeleted(*metadata) {
+		return
+	}
+
+	if _, ok := metadata.CustomMetadata[KVSyncVersionKey]; !ok {
+		return
+	}
+	return &KVSyncChange{Path: secretPath, Action: KVSyncDelete}, nil
+}
+
+func (s *KVSync) apply(change KVSyncChange) (err error) {
+	destinationPath := s.destinationPath(change.Path)
+	if change.Action == KVSyncDelete {
+		if s.options.Destroy {
+			return s.destination.DestroyAll(destinationPath)
+		}
+		return s.destination.Delete(destinationPath)
+	}
+
+	sourcePath := s.sourcePath(change.Path)
+	data := map[string]interface{}{}
+	metadata, err := s.source.ReadVersion(sourcePath, change.SourceVersion, &data)
+	if err != nil {
+		return
+	}
+
+	if metadata == nil {
+		return fmt.Errorf("version %v of %v is not found", change.SourceVersion, sourcePath)
+	}
+
+	sourceMetadata, err := s.source.ReadMetadata(sourcePath)
+	if err != nil {
+		return
+	}
+
+	destinationMetadata, err := s.destination.ReadMetadata(destinationPath)
+	if err != nil {
+		return
+	}
+
+	// custom metadata set on the destination is kept, source custom metadata and the sync marker are merged over it
+	customMetadata := map[string]string{}
+	for _, metadata := range []*KVHistoryMetadata{destinationMetadata, sourceMetadata} {
+		if metadata == nil {
+			continue
+		}
+		for key, value := range metadata.CustomMetadata {
+			customMetadata[key] = value
+		}
+	}
+	customMetadata[KVSyncVersionKey] = strconv.Itoa(change.SourceVersion)
+
+	_, err = s.destination.Write(destinationPath, data)
+	if err != nil {
+		return
+	}
+	return s.destination.WriteMetadata(destinationPath, KVSecretMetadata{CustomMetadata: customMetadata})
+}
+
+// list return secret paths under root relative to root, filtered by Include and Exclude
+func (s *KVSync) list(kv KV, root string) (paths []string, err error) {
+	all, err := kv.ListAll(root)
+	if err != nil {
+		return
+	}
+
+	paths = []string{}
+	for _, secretPath := range all {
+		if root != "" {
+			secretPath = strings.TrimPrefix(secretPath, root+"/")
+		}
+		if s.included(secretPath) {
+			paths = append(paths, secretPath)
+		}
+	}
+	return
+}
+
+func (s *KVSync) included(secretPath string) bool {
+	for _, pattern := range s.options.Exclude {
+		if matched, _ := path.Match(pattern, secretPath); matched {
+			return false
+		}
+	}
+
+	if len(s.options.Include) == 0 {
+		return true
+	}
+
+	for _, pattern := range s.options.Include {
+		if matched, _ := path.Match(pattern, secretPath); matched {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *KVSync) sourcePath(secretPath string) string {
+	return joinKVPath(s.options.Path, secretPath)
+}
+
+func (s *KVSync) destinationPath(secretPath string) string {
+	return joinKVPath(s.options.DestinationPath, secretPath)
+}
+
+func joinKVPath(root string, secretPath string) string {
+	if root == "" {
+		return secretPath
+	}
+	return fmt.Sprintf("%v/%v", root, secretPath)
+}
+
+func isCurrentVersionDeleted(metadata KVHistoryMetadata) bool {
+	version, ok := metadata.Versions[strconv.Itoa(metadata.CurrentVersion)]
+	return ok && (version.Destroyed || version.DeletionTime != nil)
+}
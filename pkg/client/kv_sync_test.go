@@ -0,0 +1,287 @@
+package client_test
+
+import (
+	"context"
+	"fmt"
+	. "github.com/jasoet/vault-client/pkg/client"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"sort"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+type memorySecret struct {
+	versions       []map[string]interface{}
+	customMetadata map[string]string
+	deleted        bool
+}
+
+// memoryKV implement the KV methods used by KVSync, other methods panic through the nil embedded KV
+type memoryKV struct {
+	KV
+	secrets map[string]*memorySecret
+	writes  int
+}
+
+func newMemoryKV() *memoryKV {
+	return &memoryKV{secrets: map[string]*memorySecret{}}
+}
+
+func (m *memoryKV) ListAll(path string) ([]string, error) {
+	list := []string{}
+	for secretPath := range m.secrets {
+		if path == "" || strings.HasPrefix(secretPath, path+"/") {
+			list = append(list, secretPath)
+		}
+	}
+	sort.Strings(list)
+	return list, nil
+}
+
+func (m *memoryKV) ReadMetadata(path string) (*KVHistoryMetadata, error) {
+	secret, ok := m.secrets[path]
+	if !ok {
+		return nil, nil
+	}
+
+	metadata := &KVHistoryMetadata{CurrentVersion: len(secret.versions), CustomMetadata: secret.customMetadata, Versions: map[string]KVMetadata{}}
+	for index := range secret.versions {
+		version := KVMetadata{Version: index + 1}
+		if secret.deleted && index+1 == len(secret.versions) {
+			now := time.Now()
+			version.DeletionTime = &now
+		}
+		metadata.Versions[strconv.Itoa(index+1)] = version
+	}
+	return metadata, nil
+}
+
+func (m *memoryKV) ReadVersion(path string, version int, output interface{}) (*KVMetadata, error) {
+	secret, ok := m.secrets[path]
+	if !ok || version > len(secret.versions) {
+		return nil, nil
+	}
+
+	data := output.(*map[string]interface{})
+	for key, value := range secret.versions[version-1] {
+		(*data)[key] = value
+	}
+	return &KVMetadata{Version: version}, nil
+}
+
+func (m *memoryKV) Write(path string, input interface{}) (*KVMetadata, error) {
+	secret, ok := m.secrets[path]
+	if !ok {
+		secret = &memorySecret{}
+		m.secrets[path] = secret
+	}
+	secret.versions = append(secret.versions, input.(map[string]interface{}))
+	secret.deleted = false
+	m.writes++
+	return &KVMetadata{Version: len(secret.versions)}, nil
+}
+
+func (m *memoryKV) WriteMetadata(path string, metadata KVSecretMetadata) error {
+	secret, ok := m.secrets[path]
+	if !ok {
+		return fmt.Errorf("%v is not found", path)
+	}
+	// like Vault, empty custom metadata keep the existing one unless it is cleared
+	if len(metadata.CustomMetadata) > 0 || metadata.ClearCustomMetadata {
+		secret.customMetadata = metadata.CustomMetadata
+	}
+	return nil
+}
+
+func (m *memoryKV) Delete(path string) error {
+	secret, ok := m.secrets[path]
+	if !ok {
+		return fmt.Errorf("%v is not found", path)
+	}
+	secret.deleted = true
+	return nil
+}
+
+func (m *memoryKV) DestroyAll(path string) error {
+	delete(m.secrets, path)
+	return nil
+}
+
+func TestKVSync(t *testing.T) {
+	actions := func(report *KVSyncReport) map[string]KVSyncAction {
+		result := map[string]KVSyncAction{}
+		for _, change := range report.Changes {
+			require.NoError(t, change.Err)
+			result[change.Path] = change.Action
+		}
+		return result
+	}
+
+	t.Run("should copy new and changed secrets", func(t *testing.T) {
+		source, destination := newMemoryKV(), newMemoryKV()
+		_, _ = source.Write("app/first", map[string]interface{}{"password": "one"})
+		_ = source.WriteMetadata("app/first", KVSecretMetadata{CustomMetadata: map[string]string{"owner": "platform"}})
+		_, _ = source.Write("app/second", map[string]interface{}{"password": "two"})
+
+		sync, err := NewKVSync(source, destination, KVSyncOptions{})
+		require.NoError(t, err)
+
+		report, err := sync.Sync()
+		require.NoError(t, err)
+		assert.Equal(t, map[string]KVSyncAction{"app/first": KVSyncCreate, "app/second": KVSyncCreate}, actions(report))
+		assert.Equal(t, "one", destination.secrets["app/first"].versions[0]["password"])
+		assert.Equal(t, map[string]string{"owner": "platform", KVSyncVersionKey: "1"}, destination.secrets["app/first"].customMetadata)
+
+		report, err = sync.Sync()
+		require.NoError(t, err)
+		assert.Empty(t, report.Changes)
+		assert.Equal(t, 2, report.Unchanged)
+
+		_, _ = source.Write("app/second", map[string]interface{}{"password": "three"})
+		report, err = sync.Sync()
+		require.NoError(t, err)
+		assert.Equal(t, map[string]KVSyncAction{"app/second": KVSyncUpdate}, actions(report))
+		assert.Equal(t, "three", destination.secrets["app/second"].versions[1]["password"])
+	})
+
+	t.Run("should keep destination custom metadata", func(t *testing.T) {
+		source, destination := newMemoryKV(), newMemoryKV()
+		_, _ = source.Write("first", map[string]interface{}{"password": "one"})
+		_ = source.WriteMetadata("first", KVSecretMetadata{CustomMetadata: map[string]string{"owner": "platform"}})
+
+		sync, err := NewKVSync(source, destination, KVSyncOptions{})
+		require.NoError(t, err)
+		_, err = sync.Sync()
+		require.NoError(t, err)
+
+		_ = destination.WriteMetadata("first", KVSecretMetadata{CustomMetadata: map[string]string{
+			"owner": "dr", "region": "eu", KVSyncVersionKey: "1",
+		}})
+		_, _ = source.Write("first", map[string]interface{}{"password": "two"})
+
+		report, err := sync.Sync()
+		require.NoError(t, err)
+		assert.Equal(t, map[string]KVSyncAction{"first": KVSyncUpdate}, actions(report))
+		assert.Equal(t, map[string]string{"owner": "platform", "region": "eu", KVSyncVersionKey: "2"}, destination.secrets["first"].customMetadata)
+	})
+
+	t.Run("should only report changes on dry run", func(t *testing.T) {
+		source, destination := newMemoryKV(), newMemoryKV()
+		_, _ = source.Write("first", map[string]interface{}{"password": "one"})
+
+		sync, err := NewKVSync(source, destination, KVSyncOptions{DryRun: true})
+		require.NoError(t, err)
+
+		report, err := sync.Sync()
+		require.NoError(t, err)
+		assert.True(t, report.DryRun)
+		assert.Equal(t, map[string]KVSyncAction{"first": KVSyncCreate}, actions(report))
+		assert.Equal(t, 0, destination.writes)
+	})
+
+	t.Run("should filter paths with include and exclude globs", func(t *testing.T) {
+		source, destination := newMemoryKV(), newMemoryKV()
+		for _, path := range []string{"team/app/db", "team/app/cache", "team/web/db", "other/db"} {
+			_, _ = source.Write(path, map[string]interface{}{"password": path})
+		}
+
+		sync, err := NewKVSync(source, destination, KVSyncOptions{Path: "team", DestinationPath: "dr/team", Include: []string{"app/*", "web/*"}, Exclude: []string{"*/cache"}})
+		require.NoError(t, err)
+
+		report, err := sync.Sync()
+		require.NoError(t, err)
+		assert.Equal(t, map[string]KVSyncAction{"app/db": KVSyncCreate, "web/db": KVSyncCreate}, actions(report))
+		assert.Contains(t, destination.secrets, "dr/team/app/db")
+		assert.Contains(t, destination.secrets, "dr/team/web/db")
+		assert.Len(t, destination.secrets, 2)
+	})
+
+	t.Run("should soft delete synced secrets only", func(t *testing.T) {
+		source, destination := newMemoryKV(), newMemoryKV()
+		_, _ = source.Write("removed", map[string]interface{}{"password": "one"})
+		_, _ = source.Write("deleted", map[string]interface{}{"password": "two"})
+		_, _ = destination.Write("manual", map[string]interface{}{"password": "three"})
+
+		sync, err := NewKVSync(source, destination, KVSyncOptions{Delete: true})
+		require.NoError(t, err)
+		_, err = sync.Sync()
+		require.NoError(t, err)
+
+		_ = source.DestroyAll("removed")
+		source.secrets["deleted"].deleted = true
+
+		report, err := sync.Sync()
+		require.NoError(t, err)
+		assert.Equal(t, map[string]KVSyncAction{"removed": KVSyncDelete, "deleted": KVSyncDelete}, actions(report))
+		assert.True(t, destination.secrets["removed"].deleted)
+		assert.True(t, destination.secrets["deleted"].deleted)
+		assert.Len(t, destination.secrets["deleted"].versions, 1)
+		assert.False(t, destination.secrets["manual"].deleted)
+
+		report, err = sync.Sync()
+		require.NoError(t, err)
+		assert.Empty(t, report.Changes)
+
+		source.secrets["deleted"].deleted = false
+		report, err = sync.Sync()
+		require.NoError(t, err)
+		assert.Equal(t, map[string]KVSyncAction{"deleted": KVSyncUpdate}, actions(report))
+		assert.False(t, destination.secrets["deleted"].deleted)
+	})
+
+	t.Run("should destroy synced secrets when destroy is set", func(t *testing.T) {
+		source, destination := newMemoryKV(), newMemoryKV()
+		_, _ = source.Write("removed", map[string]interface{}{"password": "one"})
+		_, _ = destination.Write("manual", map[string]interface{}{"password": "three"})
+
+		sync, err := NewKVSync(source, destination, KVSyncOptions{Delete: true, Destroy: true})
+		require.NoError(t, err)
+		_, err = sync.Sync()
+		require.NoError(t, err)
+
+		_ = source.DestroyAll("removed")
+
+		report, err := sync.Sync()
+		require.NoError(t, err)
+		assert.Equal(t, map[string]KVSyncAction{"removed": KVSyncDelete}, actions(report))
+		list, err := destination.ListAll("")
+		require.NoError(t, err)
+		assert.Equal(t, []string{"manual"}, list)
+	})
+
+	t.Run("should reject invalid glob", func(t *testing.T) {
+		_, err := NewKVSync(newMemoryKV(), newMemoryKV(), KVSyncOptions{Include: []string{"[app"}})
+		assert.Error(t, err)
+	})
+
+	t.Run("run should sync until context is done", func(t *testing.T) {
+		source, destination := newMemoryKV(), newMemoryKV()
+		_, _ = source.Write("first", map[string]interface{}{"password": "one"})
+
+		sync, err := NewKVSync(source, destination, KVSyncOptions{})
+		require.NoError(t, err)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		runs := 0
+		err = sync.Run(ctx, 10*time.Millisecond, func(report *KVSyncReport, err error) {
+			require.NoError(t, err)
+			runs++
+			if runs == 2 {
+				cancel()
+			}
+		})
+		assert.Equal(t, context.Canceled, err)
+		assert.Equal(t, 2, runs)
+		assert.Equal(t, 1, destination.writes)
+	})
+	t.Run("run should reject non positive interval", func(t *testing.T) {
+		sync, err := NewKVSync(newMemoryKV(), newMemoryKV(), KVSyncOptions{})
+		require.NoError(t, err)
+
+		err = sync.Run(context.Background(), 0, nil)
+		assert.EqualError(t, err, "interval must be positive: 0s")
+	})
+}
@@ -3,6 +3,7 @@
 package client_test
 
 import (
+	"context"
 	"github.com/hashicorp/vault/api"
 	. "github.com/jasoet/vault-client/pkg/client"
 	"github.com/stretchr/testify/assert"
@@ -23,6 +24,14 @@ func (ctx *kvTestCtx) setup(t *testing.T) {
 
 	client.SetToken(os.Getenv("TEST_VAULT_TOKEN"))
 	ctx.vaultClient = client
+
+	system, err := NewSystem(client)
+	assert.Nil(t, err)
+
+	readyCtx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+	err = system.WaitUntilReady(readyCtx, time.Second)
+	assert.Nil(t, err)
 }
 
 func TestKV(t *testing.T) {
@@ -129,4 +138,192 @@ func TestKV(t *testing.T) {
 		assert.Equal(t, 3, historyMetadata.CurrentVersion)
 	})
 
+	t.Run("wrapped secret data should be unwrapped to latest version", func(t *testing.T) {
+		info, err := kv.ReadWrapped(dataPath, WrapOptions{Ttl: 300})
+		assert.Nil(t, err)
+		assert.NotNil(t, info)
+		assert.NotEmpty(t, info.Token)
+
+		output := new(DatabaseConfig)
+		metadata, err := kv.Unwrap(info.Token, output)
+		assert.Nil(t, err)
+		assert.NotNil(t, metadata)
+		assert.Equal(t, 3, metadata.Version)
+		assert.Equal(t, sampleData.Username, output.Username)
+	})
+
+	t.Run("history should be ordered by version", func(t *testing.T) {
+		history, err := kv.History(dataPath)
+		assert.Nil(t, err)
+		assert.Equal(t, 3, len(history))
+		for index, version := range history {
+			assert.Equal(t, index+1, version.Version)
+			assert.Nil(t, version.DeletionTime)
+		}
+	})
+
+	t.Run("diff should return masked field changes", func(t *testing.T) {
+		diff, err := kv.Diff(dataPath, 2, 3, KVDiffOptions{})
+		assert.Nil(t, err)
+
+		fields := map[string]KVFieldDiff{}
+		for _, field := range diff {
+			fields[field.Field] = field
+		}
+		assert.Equal(t, KVFieldChanged, fields["password"].Type)
+		assert.Equal(t, KVMaskedValue, fields["password"].OldValue)
+		assert.Equal(t, KVFieldChanged, fields["plugin_name"].Type)
+		assert.NotContains(t, fields, "connection_url")
+
+		diff, err = kv.Diff(dataPath, 2, 3, KVDiffOptions{ShowValues: true})
+		assert.Nil(t, err)
+		assert.Contains(t, diff, KVFieldDiff{Field: "password", Type: KVFieldChanged, OldValue: "password for data 2", NewValue: "password for data 3"})
+	})
+
+	t.Run("rollback should write old version as current version", func(t *testing.T) {
+		metadata, err := kv.Rollback(dataPath, 2)
+		assert.Nil(t, err)
+		assert.NotNil(t, metadata)
+		assert.Equal(t, 4, metadata.Version)
+
+		output := new(DatabaseConfig)
+		_, err = kv.Read(dataPath, output)
+		assert.Nil(t, err)
+		assert.Equal(t, "password for data 2", output.Password)
+
+		diff, err := kv.Diff(dataPath, 2, 4, KVDiffOptions{})
+		assert.Nil(t, err)
+		assert.Empty(t, diff)
+
+		_, err = kv.Rollback(dataPath, 4)
+		assert.NotNil(t, err)
+	})
+
+	t.Run("custom metadata should be written and read", func(t *testing.T) {
+		maxVersions, casRequired, deleteVersionAfter := 5, false, "720h"
+		err := kv.WriteMetadata(dataPath, KVSecretMetadata{
+			MaxVersions:        &maxVersions,
+			CasRequired:        &casRequired,
+			DeleteVersionAfter: &deleteVersionAfter,
+			CustomMetadata:     map[string]string{"owner": "platform", KVRotateAfterKey: "2020-01-01"},
+		})
+		assert.Nil(t, err)
+
+		historyMetadata, err := kv.ReadMetadata(dataPath)
+		assert.Nil(t, err)
+		assert.Equal(t, 5, historyMetadata.MaxVersion)
+		assert.Equal(t, "720h0m0s", historyMetadata.DeleteVersionAfter)
+		assert.Equal(t, "platform", historyMetadata.CustomMetadata["owner"])
+	})
+
+	t.Run("metadata should be reset and custom metadata cleared", func(t *testing.T) {
+		clearedPath := "metadata/cleared"
+		_, err := kv.Write(clearedPath, sampleData)
+		assert.Nil(t, err)
+		err = kv.WriteMetadata(clearedPath, KVSecretMetadata{CustomMetadata: map[string]string{"owner": "platform"}})
+		assert.Nil(t, err)
+
+		maxVersions := 3
+		err = kv.WriteMetadata(clearedPath, KVSecretMetadata{MaxVersions: &maxVersions})
+		assert.Nil(t, err)
+		historyMetadata, err := kv.ReadMetadata(clearedPath)
+		assert.Nil(t, err)
+		assert.Equal(t, 3, historyMetadata.MaxVersion)
+		assert.Equal(t, "platform", historyMetadata.CustomMetadata["owner"])
+
+		maxVersions = 0
+		err = kv.WriteMetadata(clearedPath, KVSecretMetadata{MaxVersions: &maxVersions})
+		assert.Nil(t, err)
+		historyMetadata, err = kv.ReadMetadata(clearedPath)
+		assert.Nil(t, err)
+		assert.Equal(t, 0, historyMetadata.MaxVersion)
+
+		err = kv.WriteMetadata(clearedPath, KVSecretMetadata{ClearCustomMetadata: true})
+		assert.Nil(t, err)
+		historyMetadata, err = kv.ReadMetadata(clearedPath)
+		assert.Nil(t, err)
+		assert.Empty(t, historyMetadata.CustomMetadata)
+
+		err = kv.DestroyAll(clearedPath)
+		assert.Nil(t, err)
+	})
+
+	t.Run("secret past rotate-after should be listed as overdue", func(t *testing.T) {
+		freshPath := "rotation/fresh"
+		_, err := kv.Write(freshPath, sampleData)
+		assert.Nil(t, err)
+		err = kv.WriteMetadata(freshPath, KVSecretMetadata{CustomMetadata: map[string]string{KVRotateAfterKey: "2999-01-01"}})
+		assert.Nil(t, err)
+
+		invalidPath := "rotation/nested/invalid"
+		_, err = kv.Write(invalidPath, sampleData)
+		assert.Nil(t, err)
+		err = kv.WriteMetadata(invalidPath, KVSecretMetadata{CustomMetadata: map[string]string{KVRotateAfterKey: "soon"}})
+		assert.Nil(t, err)
+
+		all, err := kv.ListAll("")
+		assert.Nil(t, err)
+		assert.Contains(t, all, dataPath)
+		assert.Contains(t, all, invalidPath)
+
+		overdue, err := kv.ListRotationOverdue("", time.Now())
+		assert.Nil(t, err)
+
+		paths := map[string]KVRotationStatus{}
+		for _, status := range overdue {
+			paths[status.Path] = status
+		}
+		assert.Contains(t, paths, dataPath)
+		assert.Nil(t, paths[dataPath].Err)
+		assert.NotContains(t, paths, freshPath)
+		assert.Contains(t, paths, invalidPath)
+		assert.NotNil(t, paths[invalidPath].Err)
+
+		_ = kv.DestroyAll(freshPath)
+		_ = kv.DestroyAll(invalidPath)
+	})
+
+	t.Run("sync should copy secrets to another mount", func(t *testing.T) {
+		destination, err := NewKV(ctx.vaultClient, "kv-sync-path")
+		assert.Nil(t, err)
+		_ = destination.Enable()
+
+		sync, err := NewKVSync(kv, destination, KVSyncOptions{Path: "sample", DestinationPath: "dr/sample", Delete: true})
+		assert.Nil(t, err)
+
+		report, err := sync.Sync()
+		assert.Nil(t, err)
+		assert.Empty(t, report.Failed())
+		assert.Contains(t, report.Changes, KVSyncChange{Path: "first", Action: KVSyncCreate, SourceVersion: 4})
+
+		output := new(DatabaseConfig)
+		_, err = destination.Read("dr/sample/first", output)
+		assert.Nil(t, err)
+		assert.Equal(t, "password for data 2", output.Password)
+
+		report, err = sync.Sync()
+		assert.Nil(t, err)
+		assert.Empty(t, report.Changes)
+
+		_ = destination.DestroyAll("dr/sample/first")
+	})
+
+	t.Run("scan should report weak password without values", func(t *testing.T) {
+		weakPath := "scan/weak"
+		_, err := kv.Write(weakPath, map[string]interface{}{"password": "password"})
+		assert.Nil(t, err)
+
+		report, err := kv.Scan("scan", KVScanOptions{})
+		assert.Nil(t, err)
+		assert.Equal(t, 1, report.Scanned)
+		assert.Contains(t, report.Findings, KVFinding{
+			Type:    KVWeakPassword,
+			Path:    weakPath,
+			Field:   "password",
+			Message: "password entropy is 22.0 bits, less than 40 bits",
+		})
+
+		_ = kv.DestroyAll(weakPath)
+	})
+
 }
@@ -4,23 +4,36 @@ import (
 	"fmt"
 	"github.com/hashicorp/vault/api"
 	"github.com/jasoet/vault-client/pkg/util"
+	"net/http"
+	"strings"
+	"time"
 )
 
+const defaultBatchWorkers = 10
+
 type leaseEngine struct {
 	vaultClient *api.Client
+	batch       BatchOptions
 }
 
 func (l leaseEngine) Lookup(leaseId string) (detail *LeaseDetail, err error) {
+	detail, err = l.lookup(leaseId)
+	if err == nil && detail == nil {
+		err = fmt.Errorf("%v is not found", leaseId)
+	}
+	return
+}
+
+// lookup return nil detail without error when the lease does not exist
+func (l leaseEngine) lookup(leaseId string) (detail *LeaseDetail, err error) {
 	payload := map[string]interface{}{
 		"lease_id": leaseId,
 	}
 	result, err := l.vaultClient.Logical().Write("/sys/leases/lookup", payload)
-	if err != nil {
-		return
+	if isLeaseNotFound(err) {
+		return nil, nil
 	}
-
-	if result == nil {
-		err = fmt.Errorf("%v is not found", leaseId)
+	if err != nil || result == nil {
 		return
 	}
 
@@ -29,6 +42,30 @@ func (l leaseEngine) Lookup(leaseId string) (detail *LeaseDetail, err error) {
 	return
 }
 
+// isLeaseNotFound report whether err is Vault response for unknown lease id, Vault answer lookup of
+// revoked lease with 400 `invalid lease`
+func isLeaseNotFound(err error) bool {
+	responseErr, ok := err.(*api.ResponseError)
+	if !ok {
+		return false
+	}
+
+	if responseErr.StatusCode == http.StatusNotFound {
+		return true
+	}
+
+	if responseErr.StatusCode != http.StatusBadRequest {
+		return false
+	}
+
+	for _, message := range responseErr.Errors {
+		if strings.Contains(message, "invalid lease") {
+			return true
+		}
+	}
+	return false
+}
+
 func (l leaseEngine) List(prefix string) (list []string, err error) {
 	result, err := l.vaultClient.Logical().List(fmt.Sprintf("/sys/leases/lookup/%v", prefix))
 	if err != nil || result == nil {
@@ -41,6 +78,60 @@ func (l leaseEngine) List(prefix string) (list []string, err error) {
 	return
 }
 
+// ListAll recursively list every lease id under prefix, empty prefix lists leases of all mounts
+func (l leaseEngine) ListAll(prefix string) (list []string, err error) {
+	list = []string{}
+	if prefix != "" && !strings.HasSuffix(prefix, "/") {
+		prefix = fmt.Sprintf("%v/", prefix)
+	}
+
+	result, err := l.vaultClient.Logical().List(fmt.Sprintf("/sys/leases/lookup/%v", prefix))
+	if err != nil || result == nil {
+		return
+	}
+
+	val, ok := result.Data["keys"]
+	if !ok {
+		return
+	}
+
+	for _, key := range util.ToArrStrPrefix(val.([]interface{}), prefix) {
+		if !strings.HasSuffix(key, "/") {
+			list = append(list, key)
+			continue
+		}
+
+		children, err := l.ListAll(key)
+		if err != nil {
+			return nil, err
+		}
+		list = append(list, children...)
+	}
+
+	return
+}
+
+func (l leaseEngine) Inventory(prefix string, options LeaseReportOptions) (report *LeaseReport, err error) {
+	leaseIds, err := l.ListAll(prefix)
+	if err != nil {
+		return
+	}
+
+	var details []LeaseDetail
+	var failed []string
+	for _, result := range l.LookupAll(leaseIds) {
+		if result.Err != nil || result.Detail == nil {
+			failed = append(failed, result.LeaseId)
+			continue
+		}
+		details = append(details, *result.Detail)
+	}
+
+	report = NewLeaseReport(details, options, time.Now())
+	report.Failed = append(report.Failed, failed...)
+	return
+}
+
 func (l leaseEngine) Renew(leaseId string, increment int) (err error) {
 	payload := map[string]interface{}{
 		"lease_id":  leaseId,
@@ -63,11 +154,129 @@ func (l leaseEngine) RevokePrefix(prefix string) (err error) {
 	return
 }
 
+func (l leaseEngine) RevokeWithOptions(leaseId string, options RevokeOptions) (result *RevokeResult, err error) {
+	payload, err := util.StructToMapE(options)
+	if err != nil {
+		return
+	}
+
+	payload["lease_id"] = leaseId
+	_, err = l.vaultClient.Logical().Write("/sys/leases/revoke", payload)
+	if err != nil {
+		return
+	}
+
+	detail, err := l.lookup(leaseId)
+	if err != nil {
+		return
+	}
+
+	result = &RevokeResult{Prefix: leaseId, Revoked: []string{}, Remaining: []string{}}
+	if detail == nil {
+		result.Revoked = append(result.Revoked, leaseId)
+	} else {
+		result.Remaining = append(result.Remaining, leaseId)
+	}
+	return
+}
+
+func (l leaseEngine) RevokePrefixWithOptions(prefix string, options RevokeOptions) (result *RevokeResult, err error) {
+	payload, err := util.StructToMapE(options)
+	if err != nil {
+		return
+	}
+	return l.revokePrefix("revoke-prefix", prefix, payload)
+}
+
+// RevokeForce revoke all leases under prefix ignoring backend errors, the lease ids are removed from Vault
+// even when the backend (e.g. a database) fails to remove the secret.
+// confirm must be equal to prefix to guard against forcing revocation on unintended prefix.
+func (l leaseEngine) RevokeForce(prefix string, confirm string) (result *RevokeResult, err error) {
+	if strings.Trim(prefix, "/") == "" {
+		err = fmt.Errorf("revoke-force requires non empty prefix")
+		return
+	}
+
+	if confirm != prefix {
+		err = fmt.Errorf("revoke-force on %v is not confirmed, confirm value must be equal to the prefix", prefix)
+		return
+	}
+
+	return l.revokePrefix("revoke-force", prefix, map[string]interface{}{})
+}
+
+func (l leaseEngine) revokePrefix(operation string, prefix string, payload map[string]interface{}) (result *RevokeResult, err error) {
+	before, err := l.ListAll(prefix)
+	if err != nil {
+		return
+	}
+
+	_, err = l.vaultClient.Logical().Write(fmt.Sprintf("/sys/leases/%v/%v", operation, prefix), payload)
+	if err != nil {
+		return
+	}
+
+	after, err := l.ListAll(prefix)
+	if err != nil {
+		return
+	}
+
+	remaining := map[string]bool{}
+	for _, leaseId := range after {
+		remaining[leaseId] = true
+	}
+
+	result = &RevokeResult{Prefix: prefix, Revoked: []string{}, Remaining: []string{}}
+	for _, leaseId := range before {
+		if remaining[leaseId] {
+			result.Remaining = append(result.Remaining, leaseId)
+		} else {
+			result.Revoked = append(result.Revoked, leaseId)
+		}
+	}
+	return
+}
+
 func (l leaseEngine) Tidy() (err error) {
 	_, err = l.vaultClient.Logical().Write("/sys/leases/tidy", map[string]interface{}{})
 	return
 }
 
+func (l leaseEngine) LookupAll(leaseIds []string) []LeaseResult {
+	return l.runBatch(leaseIds, func(leaseId string) (detail *LeaseDetail, err error) {
+		return l.Lookup(leaseId)
+	})
+}
+
+func (l leaseEngine) RenewAll(leaseIds []string, increment int) []LeaseResult {
+	return l.runBatch(leaseIds, func(leaseId string) (detail *LeaseDetail, err error) {
+		err = l.Renew(leaseId, increment)
+		return
+	})
+}
+
+func (l leaseEngine) RevokeAll(leaseIds []string) []LeaseResult {
+	return l.runBatch(leaseIds, func(leaseId string) (detail *LeaseDetail, err error) {
+		err = l.Revoke(leaseId)
+		return
+	})
+}
+
+func (l leaseEngine) runBatch(leaseIds []string, operation func(leaseId string) (*LeaseDetail, error)) []LeaseResult {
+	workers := l.batch.Workers
+	if workers <= 0 {
+		workers = defaultBatchWorkers
+	}
+
+	results := make([]LeaseResult, len(leaseIds))
+	util.ParallelEach(len(leaseIds), workers, l.batch.RateLimit, func(i int) {
+		detail, err := operation(leaseIds[i])
+		results[i] = LeaseResult{LeaseId: leaseIds[i], Detail: detail, Err: err}
+	})
+
+	return results
+}
+
 func DefaultLease() (lease Lease, err error) {
 	vaultClient, err := api.NewClient(api.DefaultConfig())
 	if err != nil {
@@ -82,3 +291,8 @@ func NewLease(vaultClient *api.Client) (lease Lease, err error) {
 	lease = &leaseEngine{vaultClient: vaultClient}
 	return
 }
+
+func NewLeaseWithBatchOptions(vaultClient *api.Client, options BatchOptions) (lease Lease, err error) {
+	lease = &leaseEngine{vaultClient: vaultClient, batch: options}
+	return
+}
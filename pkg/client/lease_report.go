@@ -0,0 +1,193 @@
+package client
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+)
+
+const defaultNearExpiry = 3600
+
+var defaultTtlBuckets = []int{300, 3600, 86400, 604800}
+
+// LeaseReportOptions configure how leases are aggregated by NewLeaseReport.
+// NearExpiry is a ttl threshold in seconds (default 1 hour), TtlBuckets are ascending upper bounds in seconds
+// used for the ttl histogram (default 5m, 1h, 1d, 7d), leases above the last bound fall in an open bucket.
+type LeaseReportOptions struct {
+	NearExpiry int   `json:"near_expiry"`
+	TtlBuckets []int `json:"ttl_buckets"`
+}
+
+type TtlBucket struct {
+	MaxTtl int `json:"max_ttl"` // zero for the open bucket after the last bound
+	Count  int `json:"count"`
+}
+
+type LeaseGroupReport struct {
+	Path         string      `json:"path"`
+	Count        int         `json:"count"`
+	Renewable    int         `json:"renewable"`
+	NonRenewable int         `json:"non_renewable"`
+	NearExpiry   int         `json:"near_expiry"`
+	MinTtl       int         `json:"min_ttl"`
+	MaxTtl       int         `json:"max_ttl"`
+	TtlHistogram []TtlBucket `json:"ttl_histogram"`
+}
+
+type LeaseReport struct {
+	GeneratedTime time.Time          `json:"generated_time"`
+	Total         int                `json:"total"`
+	Groups        []LeaseGroupReport `json:"groups"`
+	NearExpiry    []LeaseDetail      `json:"near_expiry"`
+	NonRenewable  []LeaseDetail      `json:"non_renewable"`
+	Failed        []string           `json:"failed"`
+}
+
+// NewLeaseReport group leases by their path (lease id without the last segment, e.g. `database/creds/readonly`)
+func NewLeaseReport(leases []LeaseDetail, options LeaseReportOptions, now time.Time) *LeaseReport {
+	nearExpiry := options.NearExpiry
+	if nearExpiry <= 0 {
+		nearExpiry = defaultNearExpiry
+	}
+
+	buckets := options.TtlBuckets
+	if len(buckets) == 0 {
+		buckets = defaultTtlBuckets
+	}
+
+	report := &LeaseReport{
+		GeneratedTime: now,
+		Groups:        []LeaseGroupReport{},
+		NearExpiry:    []LeaseDetail{},
+		NonRenewable:  []LeaseDetail{},
+		Failed:        []string{},
+	}
+
+	groups := map[string]*LeaseGroupReport{}
+	for _, lease := range leases {
+		path := leaseGroupPath(lease.LeaseId)
+		group, ok := groups[path]
+		if !ok {
+			group = &LeaseGroupReport{Path: path, MinTtl: lease.Ttl, MaxTtl: lease.Ttl, TtlHistogram: newTtlHistogram(buckets)}
+			groups[path] = group
+		}
+
+		report.Total++
+		group.Count++
+		if lease.Renewable {
+			group.Renewable++
+		} else {
+			group.NonRenewable++
+			report.NonRenewable = append(report.NonRenewable, lease)
+		}
+
+		if lease.Ttl <= nearExpiry {
+			group.NearExpiry++
+			report.NearExpiry = append(report.NearExpiry, lease)
+		}
+
+		if lease.Ttl < group.MinTtl {
+			group.MinTtl = lease.Ttl
+		}
+		if lease.Ttl > group.MaxTtl {
+			group.MaxTtl = lease.Ttl
+		}
+
+		group.TtlHistogram[ttlBucketIndex(buckets, lease.Ttl)].Count++
+	}
+
+	for _, group := range groups {
+		report.Groups = append(report.Groups, *group)
+	}
+
+	sort.Slice(report.Groups, func(i, j int) bool {
+		return report.Groups[i].Path < report.Groups[j].Path
+	})
+	sort.Slice(report.NearExpiry, func(i, j int) bool {
+		return report.NearExpiry[i].Ttl < report.NearExpiry[j].Ttl
+	})
+	sort.Slice(report.NonRenewable, func(i, j int) bool {
+		return report.NonRenewable[i].LeaseId < report.NonRenewable[j].LeaseId
+	})
+
+	return report
+}
+
+func (r LeaseReport) WriteJSON(w io.Writer) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(r)
+}
+
+// WriteCSV write one row per lease group, histogram buckets are written as `ttl_le_<seconds>` columns
+func (r LeaseReport) WriteCSV(w io.Writer) (err error) {
+	writer := csv.NewWriter(w)
+
+	header := []string{"path", "count", "renewable", "non_renewable", "near_expiry", "min_ttl", "max_ttl"}
+	if len(r.Groups) > 0 {
+		for _, bucket := range r.Groups[0].TtlHistogram {
+			if bucket.MaxTtl > 0 {
+				header = append(header, fmt.Sprintf("ttl_le_%v", bucket.MaxTtl))
+			} else {
+				header = append(header, "ttl_gt_last")
+			}
+		}
+	}
+
+	err = writer.Write(header)
+	if err != nil {
+		return
+	}
+
+	for _, group := range r.Groups {
+		row := []string{
+			group.Path,
+			fmt.Sprint(group.Count),
+			fmt.Sprint(group.Renewable),
+			fmt.Sprint(group.NonRenewable),
+			fmt.Sprint(group.NearExpiry),
+			fmt.Sprint(group.MinTtl),
+			fmt.Sprint(group.MaxTtl),
+		}
+		for _, bucket := range group.TtlHistogram {
+			row = append(row, fmt.Sprint(bucket.Count))
+		}
+
+		err = writer.Write(row)
+		if err != nil {
+			return
+		}
+	}
+
+	writer.Flush()
+	return writer.Error()
+}
+
+func leaseGroupPath(leaseId string) string {
+	index := strings.LastIndex(leaseId, "/")
+	if index < 0 {
+		return leaseId
+	}
+	return leaseId[:index]
+}
+
+func newTtlHistogram(buckets []int) []TtlBucket {
+	histogram := make([]TtlBucket, 0, len(buckets)+1)
+	for _, maxTtl := range buckets {
+		histogram = append(histogram, TtlBucket{MaxTtl: maxTtl})
+	}
+	return append(histogram, TtlBucket{})
+}
+
+func ttlBucketIndex(buckets []int, ttl int) int {
+	for i, maxTtl := range buckets {
+		if ttl <= maxTtl {
+			return i
+		}
+	}
+	return len(buckets)
+}
@@ -0,0 +1,91 @@
+package client_test
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	. "github.com/jasoet/vault-client/pkg/client"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+func TestNewLeaseReport(t *testing.T) {
+	now := time.Now()
+	leases := []LeaseDetail{
+		{LeaseId: "database/creds/readonly/a1", Ttl: 120, Renewable: true},
+		{LeaseId: "database/creds/readonly/a2", Ttl: 7200, Renewable: true},
+		{LeaseId: "database/creds/readonly/a3", Ttl: 1000000, Renewable: false},
+		{LeaseId: "database/creds/admin/b1", Ttl: 30, Renewable: true},
+	}
+
+	report := NewLeaseReport(leases, LeaseReportOptions{}, now)
+
+	t.Run("should group leases by path sorted by path", func(t *testing.T) {
+		assert.Equal(t, now, report.GeneratedTime)
+		assert.Equal(t, 4, report.Total)
+		require.Len(t, report.Groups, 2)
+		assert.Equal(t, "database/creds/admin", report.Groups[0].Path)
+		assert.Equal(t, "database/creds/readonly", report.Groups[1].Path)
+
+		readonly := report.Groups[1]
+		assert.Equal(t, 3, readonly.Count)
+		assert.Equal(t, 2, readonly.Renewable)
+		assert.Equal(t, 1, readonly.NonRenewable)
+		assert.Equal(t, 1, readonly.NearExpiry)
+		assert.Equal(t, 120, readonly.MinTtl)
+		assert.Equal(t, 1000000, readonly.MaxTtl)
+	})
+
+	t.Run("should fill default ttl histogram", func(t *testing.T) {
+		histogram := report.Groups[1].TtlHistogram
+		require.Len(t, histogram, 5)
+		assert.Equal(t, TtlBucket{MaxTtl: 300, Count: 1}, histogram[0])
+		assert.Equal(t, TtlBucket{MaxTtl: 3600, Count: 0}, histogram[1])
+		assert.Equal(t, TtlBucket{MaxTtl: 86400, Count: 1}, histogram[2])
+		assert.Equal(t, TtlBucket{MaxTtl: 604800, Count: 0}, histogram[3])
+		assert.Equal(t, TtlBucket{MaxTtl: 0, Count: 1}, histogram[4])
+	})
+
+	t.Run("should list near expiry leases by ascending ttl and non renewable leases", func(t *testing.T) {
+		require.Len(t, report.NearExpiry, 2)
+		assert.Equal(t, "database/creds/admin/b1", report.NearExpiry[0].LeaseId)
+		assert.Equal(t, "database/creds/readonly/a1", report.NearExpiry[1].LeaseId)
+
+		require.Len(t, report.NonRenewable, 1)
+		assert.Equal(t, "database/creds/readonly/a3", report.NonRenewable[0].LeaseId)
+	})
+
+	t.Run("should use custom near expiry and buckets", func(t *testing.T) {
+		custom := NewLeaseReport(leases, LeaseReportOptions{NearExpiry: 10000, TtlBuckets: []int{100}}, now)
+		assert.Len(t, custom.NearExpiry, 3)
+		assert.Equal(t, []TtlBucket{{MaxTtl: 100, Count: 1}, {MaxTtl: 0, Count: 0}}, custom.Groups[0].TtlHistogram)
+	})
+
+	t.Run("should export json", func(t *testing.T) {
+		buffer := new(bytes.Buffer)
+		err := report.WriteJSON(buffer)
+		require.NoError(t, err)
+
+		decoded := new(LeaseReport)
+		err = json.Unmarshal(buffer.Bytes(), decoded)
+		require.NoError(t, err)
+		assert.Equal(t, report.Total, decoded.Total)
+		assert.Equal(t, report.Groups, decoded.Groups)
+	})
+
+	t.Run("should export one csv row per group", func(t *testing.T) {
+		buffer := new(bytes.Buffer)
+		err := report.WriteCSV(buffer)
+		require.NoError(t, err)
+
+		rows, err := csv.NewReader(buffer).ReadAll()
+		require.NoError(t, err)
+		require.Len(t, rows, 3)
+		assert.Equal(t, []string{"path", "count", "renewable", "non_renewable", "near_expiry", "min_ttl", "max_ttl",
+			"ttl_le_300", "ttl_le_3600", "ttl_le_86400", "ttl_le_604800", "ttl_gt_last"}, rows[0])
+		assert.Equal(t, []string{"database/creds/readonly", "3", "2", "1", "1", "120", "1000000", "1", "0", "1", "0", "1"}, rows[2])
+	})
+
+}
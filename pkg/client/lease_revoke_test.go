@@ -0,0 +1,87 @@
+package client_test
+
+import (
+	"encoding/json"
+	"github.com/hashicorp/vault/api"
+	. "github.com/jasoet/vault-client/pkg/client"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// revokeServer accept every revoke and answer lease lookup with status and body, the last revoke payload is kept in revoked
+func revokeServer(t *testing.T, status int, body string, revoked map[string]interface{}) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v1/sys/leases/revoke":
+			if revoked != nil {
+				_ = json.NewDecoder(r.Body).Decode(&revoked)
+			}
+			w.WriteHeader(http.StatusNoContent)
+		case "/v1/sys/leases/lookup":
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(status)
+			_, _ = w.Write([]byte(body))
+		default:
+			t.Errorf("unexpected request %v", r.URL.Path)
+		}
+	}))
+}
+
+func newLease(t *testing.T, address string) Lease {
+	config := &api.Config{Address: address, MaxRetries: 0}
+	vaultClient, err := api.NewClient(config)
+	require.NoError(t, err)
+
+	lease, err := NewLease(vaultClient)
+	require.NoError(t, err)
+	return lease
+}
+
+func TestLeaseRevokeWithOptions(t *testing.T) {
+	leaseId := "database/creds/readonly/abc"
+
+	t.Run("should report revoked when lookup return invalid lease", func(t *testing.T) {
+		revoked := map[string]interface{}{}
+		server := revokeServer(t, http.StatusBadRequest, `{"errors":["invalid lease"]}`, revoked)
+		defer server.Close()
+
+		sync := false
+		result, err := newLease(t, server.URL).RevokeWithOptions(leaseId, RevokeOptions{Sync: &sync})
+		require.NoError(t, err)
+		assert.Equal(t, []string{leaseId}, result.Revoked)
+		assert.Empty(t, result.Remaining)
+		assert.Equal(t, map[string]interface{}{"lease_id": leaseId, "sync": false}, revoked)
+	})
+
+	t.Run("should not send sync by default", func(t *testing.T) {
+		revoked := map[string]interface{}{}
+		server := revokeServer(t, http.StatusBadRequest, `{"errors":["invalid lease"]}`, revoked)
+		defer server.Close()
+
+		_, err := newLease(t, server.URL).RevokeWithOptions(leaseId, RevokeOptions{})
+		require.NoError(t, err)
+		assert.Equal(t, map[string]interface{}{"lease_id": leaseId}, revoked)
+	})
+
+	t.Run("should report remaining when lease still exists", func(t *testing.T) {
+		server := revokeServer(t, http.StatusOK, `{"data":{"id":"database/creds/readonly/abc","ttl":60}}`, nil)
+		defer server.Close()
+
+		result, err := newLease(t, server.URL).RevokeWithOptions(leaseId, RevokeOptions{})
+		require.NoError(t, err)
+		assert.Empty(t, result.Revoked)
+		assert.Equal(t, []string{leaseId}, result.Remaining)
+	})
+
+	t.Run("should return error when lookup is denied", func(t *testing.T) {
+		server := revokeServer(t, http.StatusForbidden, `{"errors":["permission denied"]}`, nil)
+		defer server.Close()
+
+		result, err := newLease(t, server.URL).RevokeWithOptions(leaseId, RevokeOptions{})
+		assert.Error(t, err)
+		assert.Nil(t, result)
+	})
+}
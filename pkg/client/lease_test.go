@@ -98,6 +98,24 @@ func TestLease(t *testing.T) {
 		assert.GreaterOrEqual(t, len(list), 3)
 	})
 
+	t.Run("list all should return leases of nested paths", func(t *testing.T) {
+		list, err := engine.ListAll(databaseEnginePath)
+		assert.Nil(t, err)
+		assert.Contains(t, list, creds.LeaseId)
+		assert.Contains(t, list, credsTwo.LeaseId)
+		assert.Contains(t, list, credsThree.LeaseId)
+	})
+
+	t.Run("inventory should group leases by role", func(t *testing.T) {
+		report, err := engine.Inventory(databaseEnginePath, LeaseReportOptions{})
+		assert.Nil(t, err)
+		assert.NotNil(t, report)
+		assert.GreaterOrEqual(t, report.Total, 3)
+		assert.Empty(t, report.Failed)
+		assert.Len(t, report.Groups, 1)
+		assert.Equal(t, fmt.Sprintf("%v/creds/%v", databaseEnginePath, roleName), report.Groups[0].Path)
+	})
+
 	t.Run("creds lease ttl should greater than previous after renew", func(t *testing.T) {
 		// Ttl on Database Creds stored as LeaseDuration
 		detail, err := engine.Lookup(credsTwo.LeaseId)
@@ -156,6 +174,95 @@ func TestLease(t *testing.T) {
 
 	})
 
+	t.Run("batch operations should report result for every lease", func(t *testing.T) {
+		batchEngine, err := NewLeaseWithBatchOptions(ctx.vaultClient, BatchOptions{Workers: 2, RateLimit: 20})
+		assert.Nil(t, err)
+
+		var leaseIds []string
+		for i := 0; i < 4; i++ {
+			cred, err := database.GenerateCreds(roleName)
+			assert.Nil(t, err)
+			leaseIds = append(leaseIds, cred.LeaseId)
+		}
+		invalidLeaseId := databaseLeasePrefix + "invalid"
+
+		results := batchEngine.LookupAll(append(leaseIds, invalidLeaseId))
+		assert.Len(t, results, len(leaseIds)+1)
+		for i, leaseId := range leaseIds {
+			assert.Equal(t, leaseId, results[i].LeaseId)
+			assert.Nil(t, results[i].Err)
+			assert.NotNil(t, results[i].Detail)
+		}
+		assert.Equal(t, invalidLeaseId, results[len(leaseIds)].LeaseId)
+		assert.NotNil(t, results[len(leaseIds)].Err)
+
+		results = batchEngine.RenewAll(leaseIds, 300)
+		assert.Len(t, results, len(leaseIds))
+		for _, result := range results {
+			assert.Nil(t, result.Err)
+		}
+
+		results = batchEngine.RevokeAll(leaseIds)
+		assert.Len(t, results, len(leaseIds))
+		for _, result := range results {
+			assert.Nil(t, result.Err)
+		}
+
+		for _, result := range batchEngine.LookupAll(leaseIds) {
+			assert.NotNil(t, result.Err)
+			assert.Nil(t, result.Detail)
+		}
+	})
+
+	t.Run("sync revoke should report revoked lease", func(t *testing.T) {
+		cred, err := database.GenerateCreds(roleName)
+		assert.Nil(t, err)
+
+		sync := true
+		result, err := engine.RevokeWithOptions(cred.LeaseId, RevokeOptions{Sync: &sync})
+		assert.Nil(t, err)
+		assert.NotNil(t, result)
+		assert.Equal(t, []string{cred.LeaseId}, result.Revoked)
+		assert.Empty(t, result.Remaining)
+	})
+
+	t.Run("sync revoke prefix should report all revoked leases", func(t *testing.T) {
+		cred, err := database.GenerateCreds(roleName)
+		assert.Nil(t, err)
+		credTwo, err := database.GenerateCreds(roleName)
+		assert.Nil(t, err)
+
+		sync := true
+		result, err := engine.RevokePrefixWithOptions(databaseLeasePrefix, RevokeOptions{Sync: &sync})
+		assert.Nil(t, err)
+		assert.NotNil(t, result)
+		assert.Contains(t, result.Revoked, cred.LeaseId)
+		assert.Contains(t, result.Revoked, credTwo.LeaseId)
+		assert.Empty(t, result.Remaining)
+	})
+
+	t.Run("revoke force should require confirmation", func(t *testing.T) {
+		cred, err := database.GenerateCreds(roleName)
+		assert.Nil(t, err)
+
+		result, err := engine.RevokeForce(databaseLeasePrefix, "")
+		assert.NotNil(t, err)
+		assert.Nil(t, result)
+
+		result, err = engine.RevokeForce("", "")
+		assert.NotNil(t, err)
+		assert.Nil(t, result)
+
+		detail, err := engine.Lookup(cred.LeaseId)
+		assert.Nil(t, err)
+		assert.NotNil(t, detail)
+
+		result, err = engine.RevokeForce(databaseLeasePrefix, databaseLeasePrefix)
+		assert.Nil(t, err)
+		assert.NotNil(t, result)
+		assert.Contains(t, result.Revoked, cred.LeaseId)
+	})
+
 	t.Run("tidy should not return err", func(t *testing.T) {
 		err = engine.Tidy()
 		assert.Nil(t, err)
@@ -0,0 +1,162 @@
+package client
+
+import (
+	"fmt"
+	"gopkg.in/go-jose/go-jose.v2"
+	"gopkg.in/go-jose/go-jose.v2/jwt"
+	"sync"
+	"time"
+)
+
+// OIDCKeySet is the source of token signing keys, Identity read them from `identity/oidc/.well-known/keys`
+type OIDCKeySet interface {
+	ReadOIDCPublicKeys() (*jose.JSONWebKeySet, error)
+}
+
+const defaultOIDCRefreshInterval = 30 * time.Second
+
+// OIDCVerifierOptions ClientId is required and always checked against `aud`, Issuer is checked against `iss`
+// when not empty, zero Leeway use jwt.DefaultLeeway for clock skew on `exp`, `nbf` and `iat`.
+// RefreshInterval is the minimum time between key set reads caused by unknown key id (default 30 seconds).
+type OIDCVerifierOptions struct {
+	Issuer          string        `json:"issuer"`
+	ClientId        string        `json:"client_id"`
+	Leeway          time.Duration `json:"leeway"`
+	RefreshInterval time.Duration `json:"refresh_interval"`
+}
+
+// OIDCClaims Subject is the entity id, Extra hold claims added by the role template
+type OIDCClaims struct {
+	jwt.Claims
+	Namespace string                 `json:"namespace,omitempty"`
+	Extra     map[string]interface{} `json:"-"`
+}
+
+// OIDCVerifier validate Vault identity tokens locally. Keys are cached and refreshed when a token is signed
+// by an unknown key id, so key rotation does not need a restart. Refresh happen at most once per RefreshInterval,
+// tokens with unknown key id are rejected without reading Vault until the next refresh is allowed.
+type OIDCVerifier struct {
+	keySet  OIDCKeySet
+	options OIDCVerifierOptions
+
+	mutex         sync.RWMutex
+	keys          *jose.JSONWebKeySet
+	refreshedTime time.Time
+}
+
+// NewOIDCVerifier fail without ClientId, otherwise tokens issued for any role of the same Vault would be accepted
+func NewOIDCVerifier(keySet OIDCKeySet, options OIDCVerifierOptions) (verifier *OIDCVerifier, err error) {
+	if options.ClientId == "" {
+		err = fmt.Errorf("oidc verifier requires client id")
+		return
+	}
+
+	if options.Leeway == 0 {
+		options.Leeway = jwt.DefaultLeeway
+	}
+
+	if options.RefreshInterval <= 0 {
+		options.RefreshInterval = defaultOIDCRefreshInterval
+	}
+	verifier = &OIDCVerifier{keySet: keySet, options: options}
+	return
+}
+
+func (v *OIDCVerifier) Verify(token string) (claims *OIDCClaims, err error) {
+	parsed, err := jwt.ParseSigned(token)
+	if err != nil {
+		return
+	}
+
+	if len(parsed.Headers) != 1 {
+		err = fmt.Errorf("token must have exactly one signature")
+		return
+	}
+	header := parsed.Headers[0]
+
+	key, err := v.key(header.KeyID)
+	if err != nil {
+		return
+	}
+
+	if key.Algorithm != "" && key.Algorithm != header.Algorithm {
+		err = fmt.Errorf("token algorithm %v does not match key algorithm %v", header.Algorithm, key.Algorithm)
+		return
+	}
+
+	result := new(OIDCClaims)
+	extra := map[string]interface{}{}
+	err = parsed.Claims(key, result, &extra)
+	if err != nil {
+		return
+	}
+
+	expected := jwt.Expected{Issuer: v.options.Issuer, Audience: jwt.Audience{v.options.ClientId}, Time: time.Now()}
+	err = result.ValidateWithLeeway(expected, v.options.Leeway)
+	if err != nil {
+		return
+	}
+
+	for _, name := range []string{"iss", "sub", "aud", "exp", "nbf", "iat", "jti", "namespace"} {
+		delete(extra, name)
+	}
+	result.Extra = extra
+
+	return result, nil
+}
+
+// key return cached key, the key set is read from Vault outside the lock so verification of known keys
+// is not blocked by the refresh
+func (v *OIDCVerifier) key(keyId string) (key jose.JSONWebKey, err error) {
+	v.mutex.RLock()
+	key, ok := v.cachedKey(keyId)
+	v.mutex.RUnlock()
+	if ok {
+		return
+	}
+
+	v.mutex.Lock()
+	key, ok = v.cachedKey(keyId)
+	refresh := !ok && time.Since(v.refreshedTime) >= v.options.RefreshInterval
+	if refresh {
+		v.refreshedTime = time.Now()
+	}
+	v.mutex.Unlock()
+
+	if ok {
+		return
+	}
+
+	if !refresh {
+		err = fmt.Errorf("signing key %v is not found", keyId)
+		return
+	}
+
+	keySet, err := v.keySet.ReadOIDCPublicKeys()
+	if err != nil {
+		return
+	}
+
+	v.mutex.Lock()
+	v.keys = keySet
+	key, ok = v.cachedKey(keyId)
+	v.mutex.Unlock()
+
+	if !ok {
+		err = fmt.Errorf("signing key %v is not found", keyId)
+	}
+	return
+}
+
+// cachedKey must be called with the mutex held
+func (v *OIDCVerifier) cachedKey(keyId string) (key jose.JSONWebKey, ok bool) {
+	if v.keys == nil {
+		return
+	}
+
+	keys := v.keys.Key(keyId)
+	if len(keys) == 0 {
+		return
+	}
+	return keys[0], true
+}
@@ -0,0 +1,141 @@
+package client_test
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	. "github.com/jasoet/vault-client/pkg/client"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/go-jose/go-jose.v2"
+	"gopkg.in/go-jose/go-jose.v2/jwt"
+	"testing"
+	"time"
+)
+
+type staticKeySet struct {
+	keys  []jose.JSONWebKey
+	reads int
+}
+
+func (s *staticKeySet) ReadOIDCPublicKeys() (*jose.JSONWebKeySet, error) {
+	s.reads++
+	return &jose.JSONWebKeySet{Keys: s.keys}, nil
+}
+
+func signToken(t *testing.T, key *rsa.PrivateKey, keyId string, claims interface{}) string {
+	signer, err := jose.NewSigner(
+		jose.SigningKey{Algorithm: jose.RS256, Key: jose.JSONWebKey{Key: key, KeyID: keyId}},
+		(&jose.SignerOptions{}).WithType("JWT"),
+	)
+	require.NoError(t, err)
+
+	token, err := jwt.Signed(signer).Claims(claims).CompactSerialize()
+	require.NoError(t, err)
+	return token
+}
+
+func TestOIDCVerifier(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	rotated, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	keySet := &staticKeySet{keys: []jose.JSONWebKey{
+		{Key: &key.PublicKey, KeyID: "key-1", Algorithm: string(jose.RS256), Use: "sig"},
+	}}
+
+	issuer := "http://vault:8200/v1/identity/oidc"
+	verifier, err := NewOIDCVerifier(keySet, OIDCVerifierOptions{Issuer: issuer, ClientId: "client-a"})
+	require.NoError(t, err)
+
+	now := time.Now()
+	validClaims := map[string]interface{}{
+		"iss":       issuer,
+		"sub":       "entity-id",
+		"aud":       "client-a",
+		"exp":       now.Add(time.Hour).Unix(),
+		"iat":       now.Unix(),
+		"namespace": "root",
+		"groups":    []string{"platform"},
+	}
+
+	t.Run("should verify token and return template claims as extra", func(t *testing.T) {
+		claims, err := verifier.Verify(signToken(t, key, "key-1", validClaims))
+		require.NoError(t, err)
+		assert.Equal(t, "entity-id", claims.Subject)
+		assert.Equal(t, "root", claims.Namespace)
+		assert.Equal(t, []interface{}{"platform"}, claims.Extra["groups"])
+		assert.NotContains(t, claims.Extra, "sub")
+	})
+
+	t.Run("should cache key set", func(t *testing.T) {
+		reads := keySet.reads
+		_, err := verifier.Verify(signToken(t, key, "key-1", validClaims))
+		require.NoError(t, err)
+		assert.Equal(t, reads, keySet.reads)
+	})
+
+	t.Run("should refresh key set on unknown key id at most once per interval", func(t *testing.T) {
+		keySet := &staticKeySet{keys: append([]jose.JSONWebKey{}, keySet.keys...)}
+		verifier, err := NewOIDCVerifier(keySet, OIDCVerifierOptions{Issuer: issuer, ClientId: "client-a", RefreshInterval: 50 * time.Millisecond})
+		require.NoError(t, err)
+
+		_, err = verifier.Verify(signToken(t, key, "key-1", validClaims))
+		require.NoError(t, err)
+		assert.Equal(t, 1, keySet.reads)
+
+		for i := 0; i < 5; i++ {
+			_, err = verifier.Verify(signToken(t, key, "junk", validClaims))
+			assert.Error(t, err)
+		}
+		assert.Equal(t, 1, keySet.reads)
+
+		keySet.keys = append(keySet.keys, jose.JSONWebKey{Key: &rotated.PublicKey, KeyID: "key-2", Algorithm: string(jose.RS256), Use: "sig"})
+		time.Sleep(60 * time.Millisecond)
+
+		claims, err := verifier.Verify(signToken(t, rotated, "key-2", validClaims))
+		require.NoError(t, err)
+		assert.Equal(t, "entity-id", claims.Subject)
+		assert.Equal(t, 2, keySet.reads)
+
+		_, err = verifier.Verify(signToken(t, rotated, "key-3", validClaims))
+		assert.Error(t, err)
+		assert.Equal(t, 2, keySet.reads)
+	})
+
+	t.Run("should reject token signed by other key", func(t *testing.T) {
+		_, err := verifier.Verify(signToken(t, rotated, "key-1", validClaims))
+		assert.Error(t, err)
+	})
+
+	t.Run("should require client id", func(t *testing.T) {
+		_, err := NewOIDCVerifier(keySet, OIDCVerifierOptions{Issuer: issuer})
+		assert.Error(t, err)
+	})
+
+	t.Run("should reject wrong audience, issuer and expired token", func(t *testing.T) {
+		invalid := []map[string]interface{}{
+			{"aud": "client-b"},
+			{"aud": nil},
+			{"iss": "http://other"},
+			{"exp": now.Add(-time.Hour).Unix()},
+		}
+		for _, override := range invalid {
+			claims := map[string]interface{}{}
+			for name, value := range validClaims {
+				claims[name] = value
+			}
+			for name, value := range override {
+				claims[name] = value
+			}
+
+			_, err := verifier.Verify(signToken(t, key, "key-1", claims))
+			assert.Error(t, err, "%v", override)
+		}
+	})
+
+	t.Run("should reject malformed token", func(t *testing.T) {
+		_, err := verifier.Verify("not-a-token")
+		assert.Error(t, err)
+	})
+}
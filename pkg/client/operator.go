@@ -0,0 +1,351 @@
+package client
+
+import (
+	"fmt"
+	"github.com/hashicorp/vault/api"
+	"github.com/jasoet/vault-client/pkg/util"
+)
+
+// OperatorState is the step of an unseal, rekey or generate root process
+type OperatorState string
+
+const (
+	OperatorCollecting OperatorState = "collecting" // key shares of the current keys are submitted
+	OperatorVerifying  OperatorState = "verifying"  // key shares of the new keys are submitted to verify rekey
+	OperatorCompleted  OperatorState = "completed"
+	OperatorCancelled  OperatorState = "cancelled"
+)
+
+type operatorEngine struct {
+	vaultClient *api.Client
+}
+
+func (o operatorEngine) InitStatus() (initialized bool, err error) {
+	result := struct {
+		Initialized bool `json:"initialized"`
+	}{}
+	err = sysRequest(o.vaultClient, "GET", "sys/init", nil, &result)
+	return result.Initialized, err
+}
+
+func (o operatorEngine) Init(options InitOptions) (result *InitResult, err error) {
+	payload, err := util.StructToMapE(options)
+	if err != nil {
+		return
+	}
+
+	result = new(InitResult)
+	err = sysRequest(o.vaultClient, "PUT", "sys/init", payload, result)
+	if err != nil {
+		result = nil
+	}
+	return
+}
+
+func (o operatorEngine) Seal() (err error) {
+	return sysRequest(o.vaultClient, "PUT", "sys/seal", nil, nil)
+}
+
+// Unseal start unseal process from current progress, the process is completed when Vault is already unsealed
+func (o operatorEngine) Unseal() (process *UnsealProcess, err error) {
+	process = &UnsealProcess{vaultClient: o.vaultClient}
+	err = sysRequest(o.vaultClient, "GET", "sys/seal-status", nil, &process.status)
+	if err != nil {
+		return nil, err
+	}
+
+	process.updateState()
+	return
+}
+
+// Rekey start rekey attempt, fail when another attempt is in progress
+func (o operatorEngine) Rekey(options RekeyOptions) (process *RekeyProcess, err error) {
+	payload, err := util.StructToMapE(options)
+	if err != nil {
+		return
+	}
+
+	process = &RekeyProcess{vaultClient: o.vaultClient, state: OperatorCollecting}
+	err = sysRequest(o.vaultClient, "PUT", "sys/rekey/init", payload, &process.status)
+	if err != nil {
+		return nil, err
+	}
+	return
+}
+
+// GenerateRoot start root token generation, empty pgpKey encode the token with a generated OTP,
+// otherwise the token is encrypted with the base64 encoded pgpKey
+func (o operatorEngine) GenerateRoot(pgpKey string) (process *GenerateRootProcess, err error) {
+	status := GenerateRootStatus{}
+	err = sysRequest(o.vaultClient, "GET", "sys/generate-root/attempt", nil, &status)
+	if err != nil {
+		return
+	}
+
+	process = &GenerateRootProcess{vaultClient: o.vaultClient, state: OperatorCollecting}
+	payload := map[string]interface{}{}
+	if pgpKey != "" {
+		payload["pgp_key"] = pgpKey
+	} else {
+		process.otp, err = generateOTP(status.OtpLength)
+		if err != nil {
+			return nil, err
+		}
+		payload["otp"] = process.otp
+	}
+
+	err = sysRequest(o.vaultClient, "PUT", "sys/generate-root/attempt", payload, &process.status)
+	if err != nil {
+		return nil, err
+	}
+	return
+}
+
+// UnsealProcess submit unseal key shares until threshold is reached
+type UnsealProcess struct {
+	vaultClient *api.Client
+	status      SealStatus
+	state       OperatorState
+}
+
+func (u *UnsealProcess) State() OperatorState {
+	return u.state
+}
+
+func (u *UnsealProcess) Status() SealStatus {
+	return u.status
+}
+
+func (u *UnsealProcess) Submit(key string) (status *SealStatus, err error) {
+	if u.state != OperatorCollecting {
+		err = fmt.Errorf("unseal is %v", u.state)
+		return
+	}
+
+	payload := map[string]interface{}{
+		"key": key,
+	}
+	return u.update(payload)
+}
+
+// Reset discard submitted key shares
+func (u *UnsealProcess) Reset() (err error) {
+	payload := map[string]interface{}{
+		"reset": true,
+	}
+	_, err = u.update(payload)
+	return
+}
+
+func (u *UnsealProcess) update(payload map[string]interface{}) (status *SealStatus, err error) {
+	result := SealStatus{}
+	err = sysRequest(u.vaultClient, "PUT", "sys/unseal", payload, &result)
+	if err != nil {
+		return
+	}
+
+	u.status = result
+	u.updateState()
+	return &result, nil
+}
+
+func (u *UnsealProcess) updateState() {
+	u.state = OperatorCollecting
+	if !u.status.Sealed {
+		u.state = OperatorCompleted
+	}
+}
+
+// RekeyProcess submit key shares of the current keys, then key shares of the new keys when verification is required
+type RekeyProcess struct {
+	vaultClient *api.Client
+	status      RekeyStatus
+	state       OperatorState
+	keys        []string
+	keysBase64  []string
+}
+
+func (r *RekeyProcess) State() OperatorState {
+	return r.state
+}
+
+func (r *RekeyProcess) Status() RekeyStatus {
+	return r.status
+}
+
+// Keys return new key shares once all shares of the current keys are submitted, they are PGP encrypted when
+// RekeyOptions.PgpKeys is set. When verification is required they are not active until the process is completed
+func (r *RekeyProcess) Keys() []string {
+	return r.keys
+}
+
+func (r *RekeyProcess) KeysBase64() []string {
+	return r.keysBase64
+}
+
+func (r *RekeyProcess) Submit(key string) (status *RekeyStatus, err error) {
+	switch r.state {
+	case OperatorCollecting:
+		return r.submitCurrentKey(key)
+	case OperatorVerifying:
+		return r.submitNewKey(key)
+	default:
+		err = fmt.Errorf("rekey is %v", r.state)
+		return
+	}
+}
+
+// Cancel discard the whole rekey attempt, including pending new keys
+func (r *RekeyProcess) Cancel() (err error) {
+	err = sysRequest(r.vaultClient, "DELETE", "sys/rekey/init", nil, nil)
+	if err != nil {
+		return
+	}
+
+	r.state = OperatorCancelled
+	return
+}
+
+func (r *RekeyProcess) submitCurrentKey(key string) (status *RekeyStatus, err error) {
+	payload := map[string]interface{}{
+		"key":   key,
+		"nonce": r.status.Nonce,
+	}
+	result := RekeyStatus{}
+	err = sysRequest(r.vaultClient, "PUT", "sys/rekey/update", payload, &result)
+	if err != nil {
+		return
+	}
+
+	r.status = result
+	if result.Complete {
+		r.keys, r.keysBase64 = result.Keys, result.KeysBase64
+		r.state = OperatorCompleted
+		if result.VerificationRequired {
+			r.state = OperatorVerifying
+		}
+	}
+	return &result, nil
+}
+
+func (r *RekeyProcess) submitNewKey(key string) (status *RekeyStatus, err error) {
+	payload := map[string]interface{}{
+		"key":   key,
+		"nonce": r.status.VerificationNonce,
+	}
+	result := RekeyStatus{}
+	err = sysRequest(r.vaultClient, "PUT", "sys/rekey/verify", payload, &result)
+	if err != nil {
+		return
+	}
+
+	// verify response use nonce for the verification nonce
+	result.VerificationNonce = result.Nonce
+	result.VerificationRequired = true
+	r.status = result
+	if result.Complete {
+		r.state = OperatorCompleted
+	}
+	return &result, nil
+}
+
+// GenerateRootProcess submit unseal key shares until the encoded root token is generated
+type GenerateRootProcess struct {
+	vaultClient *api.Client
+	status      GenerateRootStatus
+	state       OperatorState
+	otp         string
+}
+
+func (g *GenerateRootProcess) State() OperatorState {
+	return g.state
+}
+
+func (g *GenerateRootProcess) Status() GenerateRootStatus {
+	return g.status
+}
+
+// Otp return generated OTP, empty when the token is PGP encrypted
+func (g *GenerateRootProcess) Otp() string {
+	return g.otp
+}
+
+func (g *GenerateRootProcess) Submit(key string) (status *GenerateRootStatus, err error) {
+	if g.state != OperatorCollecting {
+		err = fmt.Errorf("generate root is %v", g.state)
+		return
+	}
+
+	payload := map[string]interface{}{
+		"key":   key,
+		"nonce": g.status.Nonce,
+	}
+	result := GenerateRootStatus{}
+	err = sysRequest(g.vaultClient, "PUT", "sys/generate-root/update", payload, &result)
+	if err != nil {
+		return
+	}
+
+	g.status = result
+	if result.Complete {
+		g.state = OperatorCompleted
+	}
+	return &result, nil
+}
+
+// RootToken decode the generated root token with the OTP, PGP encrypted token must be decrypted with DecryptPGPShare
+func (g *GenerateRootProcess) RootToken() (token string, err error) {
+	if g.state != OperatorCompleted {
+		err = fmt.Errorf("generate root is %v", g.state)
+		return
+	}
+
+	encoded := g.status.EncodedToken
+	if encoded == "" {
+		encoded = g.status.EncodedRootToken
+	}
+
+	if g.otp == "" {
+		err = fmt.Errorf("root token is PGP encrypted, decrypt it with DecryptPGPShare")
+		return
+	}
+
+	return DecodeRootToken(encoded, g.otp, g.status.OtpLength)
+}
+
+func (g *GenerateRootProcess) Cancel() (err error) {
+	err = sysRequest(g.vaultClient, "DELETE", "sys/generate-root/attempt", nil, nil)
+	if err != nil {
+		return
+	}
+
+	g.state = OperatorCancelled
+	return
+}
+
+func sysRequest(vaultClient *api.Client, method string, path string, payload map[string]interface{}, output interface{}) (err error) {
+	request := vaultClient.NewRequest(method, fmt.Sprintf("/v1/%v", path))
+	if payload != nil {
+		err = request.SetJSONBody(payload)
+		if err != nil {
+			return
+		}
+	}
+
+	return rawRequest(vaultClient, request, output)
+}
+
+func DefaultOperator() (operator Operator, err error) {
+	vaultClient, err := api.NewClient(api.DefaultConfig())
+	if err != nil {
+		return
+	}
+
+	operator = &operatorEngine{vaultClient: vaultClient}
+	return
+}
+
+func NewOperator(vaultClient *api.Client) (operator Operator, err error) {
+	operator = &operatorEngine{vaultClient: vaultClient}
+	return
+}
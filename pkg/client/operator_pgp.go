@@ -0,0 +1,129 @@
+package client
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"github.com/ProtonMail/go-crypto/openpgp"
+	"io/ioutil"
+	"math/big"
+	"strings"
+)
+
+const otpCharset = "0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz"
+
+// DecryptPGPShare decrypt base64 encoded PGP encrypted key share or root token with armored private key,
+// passphrase is only used when the private key is encrypted
+func DecryptPGPShare(encrypted string, armoredPrivateKey string, passphrase string) (plain string, err error) {
+	ciphertext, err := base64.StdEncoding.DecodeString(encrypted)
+	if err != nil {
+		return
+	}
+
+	keyRing, err := openpgp.ReadArmoredKeyRing(strings.NewReader(armoredPrivateKey))
+	if err != nil {
+		return
+	}
+
+	for _, entity := range keyRing {
+		keys := []*openpgp.Key{{PrivateKey: entity.PrivateKey}}
+		for _, subkey := range entity.Subkeys {
+			keys = append(keys, &openpgp.Key{PrivateKey: subkey.PrivateKey})
+		}
+
+		for _, key := range keys {
+			if key.PrivateKey != nil && key.PrivateKey.Encrypted {
+				err = key.PrivateKey.Decrypt([]byte(passphrase))
+				if err != nil {
+					return
+				}
+			}
+		}
+	}
+
+	message, err := openpgp.ReadMessage(bytes.NewReader(ciphertext), keyRing, nil, nil)
+	if err != nil {
+		return
+	}
+
+	content, err := ioutil.ReadAll(message.UnverifiedBody)
+	if err != nil {
+		return
+	}
+	return string(content), nil
+}
+
+// DecodeRootToken decode generated root token with the OTP, otpLength is GenerateRootStatus.OtpLength,
+// zero otpLength is the legacy base64 OTP of Vault before 1.0
+func DecodeRootToken(encodedToken string, otp string, otpLength int) (token string, err error) {
+	if otpLength == 0 {
+		encodedBytes, err := base64.StdEncoding.DecodeString(encodedToken)
+		if err != nil {
+			return "", err
+		}
+		otpBytes, err := base64.StdEncoding.DecodeString(otp)
+		if err != nil {
+			return "", err
+		}
+
+		tokenBytes, err := xorBytes(encodedBytes, otpBytes)
+		if err != nil {
+			return "", err
+		}
+		if len(tokenBytes) != 16 {
+			return "", fmt.Errorf("decoded root token is not an uuid")
+		}
+
+		encoded := hex.EncodeToString(tokenBytes)
+		return fmt.Sprintf("%v-%v-%v-%v-%v", encoded[0:8], encoded[8:12], encoded[12:16], encoded[16:20], encoded[20:32]), nil
+	}
+
+	encodedBytes, err := base64.RawStdEncoding.DecodeString(encodedToken)
+	if err != nil {
+		return
+	}
+
+	tokenBytes, err := xorBytes(encodedBytes, []byte(otp))
+	if err != nil {
+		return
+	}
+	return string(tokenBytes), nil
+}
+
+// generateOTP return random base62 OTP of length, zero length return legacy base64 encoded 16 bytes OTP
+func generateOTP(length int) (otp string, err error) {
+	if length == 0 {
+		buffer := make([]byte, 16)
+		_, err = rand.Read(buffer)
+		if err != nil {
+			return
+		}
+		return base64.StdEncoding.EncodeToString(buffer), nil
+	}
+
+	builder := strings.Builder{}
+	max := big.NewInt(int64(len(otpCharset)))
+	for i := 0; i < length; i++ {
+		index, err := rand.Int(rand.Reader, max)
+		if err != nil {
+			return "", err
+		}
+		builder.WriteByte(otpCharset[index.Int64()])
+	}
+	return builder.String(), nil
+}
+
+func xorBytes(a []byte, b []byte) (result []byte, err error) {
+	if len(a) != len(b) {
+		err = fmt.Errorf("length of encoded token and otp does not match")
+		return
+	}
+
+	result = make([]byte, len(a))
+	for i := range a {
+		result[i] = a[i] ^ b[i]
+	}
+	return
+}
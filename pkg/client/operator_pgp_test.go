@@ -0,0 +1,89 @@
+package client_test
+
+import (
+	"bytes"
+	"crypto"
+	"encoding/base64"
+	"github.com/ProtonMail/go-crypto/openpgp"
+	"github.com/ProtonMail/go-crypto/openpgp/armor"
+	"github.com/ProtonMail/go-crypto/openpgp/packet"
+	. "github.com/jasoet/vault-client/pkg/client"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func xorToken(token []byte, otp []byte) []byte {
+	result := make([]byte, len(token))
+	for i := range token {
+		result[i] = token[i] ^ otp[i]
+	}
+	return result
+}
+
+func TestDecodeRootToken(t *testing.T) {
+	t.Run("should decode base62 otp encoded token", func(t *testing.T) {
+		token := "s.5kuqQkrw3G8Yq1ub6mbCnmpc"
+		otp := "9ZtY7zZ4ifBbnBbpo9z8TNGM4y"
+		encoded := base64.RawStdEncoding.EncodeToString(xorToken([]byte(token), []byte(otp)))
+
+		decoded, err := DecodeRootToken(encoded, otp, len(otp))
+		require.NoError(t, err)
+		assert.Equal(t, token, decoded)
+	})
+
+	t.Run("should decode legacy uuid token", func(t *testing.T) {
+		token := []byte{0x6b, 0x3a, 0x9e, 0x4f, 0x1c, 0x2d, 0x4e, 0x8a, 0x9b, 0x11, 0x02, 0x33, 0xc4, 0xd5, 0xe6, 0xf7}
+		otp := []byte("0123456789abcdef")
+		encoded := base64.StdEncoding.EncodeToString(xorToken(token, otp))
+
+		decoded, err := DecodeRootToken(encoded, base64.StdEncoding.EncodeToString(otp), 0)
+		require.NoError(t, err)
+		assert.Equal(t, "6b3a9e4f-1c2d-4e8a-9b11-0233c4d5e6f7", decoded)
+	})
+
+	t.Run("should reject otp with different length", func(t *testing.T) {
+		encoded := base64.RawStdEncoding.EncodeToString([]byte("s.short"))
+		_, err := DecodeRootToken(encoded, "tooLongOtp", 10)
+		assert.Error(t, err)
+	})
+}
+
+func TestDecryptPGPShare(t *testing.T) {
+	entity, err := openpgp.NewEntity("operator", "", "operator@example.com", &packet.Config{DefaultHash: crypto.SHA256})
+	require.NoError(t, err)
+
+	privateKey := bytes.Buffer{}
+	writer, err := armor.Encode(&privateKey, openpgp.PrivateKeyType, nil)
+	require.NoError(t, err)
+	require.NoError(t, entity.SerializePrivate(writer, nil))
+	require.NoError(t, writer.Close())
+
+	share := "3f1c2a0b9e8d7c6b5a49382716f5e4d3c2b1a0f9e8d7c6b5a4938271605f4e3d"
+	ciphertext := bytes.Buffer{}
+	plain, err := openpgp.Encrypt(&ciphertext, []*openpgp.Entity{entity}, nil, nil, &packet.Config{DefaultHash: crypto.SHA256})
+	require.NoError(t, err)
+	_, err = plain.Write([]byte(share))
+	require.NoError(t, err)
+	require.NoError(t, plain.Close())
+
+	t.Run("should decrypt base64 encoded share", func(t *testing.T) {
+		decrypted, err := DecryptPGPShare(base64.StdEncoding.EncodeToString(ciphertext.Bytes()), privateKey.String(), "")
+		require.NoError(t, err)
+		assert.Equal(t, share, decrypted)
+	})
+
+	t.Run("should fail with other private key", func(t *testing.T) {
+		other, err := openpgp.NewEntity("other", "", "other@example.com", nil)
+		require.NoError(t, err)
+
+		otherKey := bytes.Buffer{}
+		writer, err := armor.Encode(&otherKey, openpgp.PrivateKeyType, nil)
+		require.NoError(t, err)
+		require.NoError(t, other.SerializePrivate(writer, nil))
+		require.NoError(t, writer.Close())
+
+		_, err = DecryptPGPShare(base64.StdEncoding.EncodeToString(ciphertext.Bytes()), otherKey.String(), "")
+		assert.Error(t, err)
+	})
+}
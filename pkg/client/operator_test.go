@@ -0,0 +1,272 @@
+package client_test
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"github.com/hashicorp/vault/api"
+	. "github.com/jasoet/vault-client/pkg/client"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// fakeOperatorVault simulate sys endpoints of a Vault with unseal threshold 2
+type fakeOperatorVault struct {
+	sealed    bool
+	progress  int
+	rootToken string
+	otp       string
+	newKeys   []string
+	cancelled []string
+}
+
+func (f *fakeOperatorVault) handler() http.Handler {
+	decode := func(r *http.Request) map[string]interface{} {
+		body := map[string]interface{}{}
+		if r.Body != nil {
+			_ = json.NewDecoder(r.Body).Decode(&body)
+		}
+		return body
+	}
+	write := func(w http.ResponseWriter, body interface{}) {
+		_ = json.NewEncoder(w).Encode(body)
+	}
+	fail := func(w http.ResponseWriter, message string) {
+		w.WriteHeader(http.StatusBadRequest)
+		write(w, map[string]interface{}{"errors": []string{message}})
+	}
+	sealStatus := func() map[string]interface{} {
+		return map[string]interface{}{"type": "shamir", "initialized": true, "sealed": f.sealed, "t": 2, "n": 3, "progress": f.progress}
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/v1/sys/seal-status", func(w http.ResponseWriter, r *http.Request) {
+		write(w, sealStatus())
+	})
+	mux.HandleFunc("/v1/sys/unseal", func(w http.ResponseWriter, r *http.Request) {
+		body := decode(r)
+		if body["reset"] == true {
+			f.progress = 0
+		} else if body["key"] != nil {
+			f.progress++
+			if f.progress == 2 {
+				f.sealed, f.progress = false, 0
+			}
+		}
+		write(w, sealStatus())
+	})
+	mux.HandleFunc("/v1/sys/generate-root/attempt", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case "PUT":
+			f.otp = decode(r)["otp"].(string)
+			f.progress = 0
+			write(w, map[string]interface{}{"nonce": "root-nonce", "started": true, "required": 2, "otp_length": len(f.rootToken)})
+		case "DELETE":
+			f.cancelled = append(f.cancelled, "generate-root")
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			write(w, map[string]interface{}{"started": false, "required": 2, "otp_length": len(f.rootToken)})
+		}
+	})
+	mux.HandleFunc("/v1/sys/generate-root/update", func(w http.ResponseWriter, r *http.Request) {
+		if decode(r)["nonce"] != "root-nonce" {
+			fail(w, "invalid nonce")
+			return
+		}
+
+		f.progress++
+		status := map[string]interface{}{"nonce": "root-nonce", "started": true, "progress": f.progress, "required": 2, "otp_length": len(f.rootToken)}
+		if f.progress == 2 {
+			encoded := make([]byte, len(f.rootToken))
+			for i := range encoded {
+				encoded[i] = f.rootToken[i] ^ f.otp[i]
+			}
+			status["complete"] = true
+			status["encoded_token"] = base64.RawStdEncoding.EncodeToString(encoded)
+		}
+		write(w, status)
+	})
+	mux.HandleFunc("/v1/sys/rekey/init", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == "DELETE" {
+			f.cancelled = append(f.cancelled, "rekey")
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+
+		body := decode(r)
+		f.progress = 0
+		write(w, map[string]interface{}{"nonce": "rekey-nonce", "started": true, "t": body["secret_threshold"], "n": body["secret_shares"], "required": 2, "verification_required": body["require_verification"] == true})
+	})
+	mux.HandleFunc("/v1/sys/rekey/update", func(w http.ResponseWriter, r *http.Request) {
+		if decode(r)["nonce"] != "rekey-nonce" {
+			fail(w, "invalid nonce")
+			return
+		}
+
+		f.progress++
+		if f.progress < 2 {
+			write(w, map[string]interface{}{"nonce": "rekey-nonce", "started": true, "progress": f.progress, "required": 2, "verification_required": true})
+			return
+		}
+		f.progress = 0
+		write(w, map[string]interface{}{"nonce": "rekey-nonce", "complete": true, "keys": f.newKeys, "verification_required": true, "verification_nonce": "verify-nonce"})
+	})
+	mux.HandleFunc("/v1/sys/rekey/verify", func(w http.ResponseWriter, r *http.Request) {
+		if decode(r)["nonce"] != "verify-nonce" {
+			fail(w, "invalid verification nonce")
+			return
+		}
+
+		f.progress++
+		if f.progress < 2 {
+			write(w, map[string]interface{}{"nonce": "verify-nonce", "started": true, "t": 2, "n": 3, "progress": f.progress})
+			return
+		}
+		write(w, map[string]interface{}{"nonce": "verify-nonce", "complete": true})
+	})
+	return mux
+}
+
+func newOperator(t *testing.T, vault *fakeOperatorVault) (Operator, func()) {
+	server := httptest.NewServer(vault.handler())
+
+	vaultClient, err := api.NewClient(&api.Config{Address: server.URL})
+	require.NoError(t, err)
+
+	operator, err := NewOperator(vaultClient)
+	require.NoError(t, err)
+	return operator, server.Close
+}
+
+func TestOperatorUnseal(t *testing.T) {
+	operator, closeServer := newOperator(t, &fakeOperatorVault{sealed: true})
+	defer closeServer()
+
+	process, err := operator.Unseal()
+	require.NoError(t, err)
+	assert.Equal(t, OperatorCollecting, process.State())
+	assert.Equal(t, 2, process.Status().Threshold)
+
+	t.Run("should report progress and reset", func(t *testing.T) {
+		status, err := process.Submit("key-1")
+		require.NoError(t, err)
+		assert.Equal(t, 1, status.Progress)
+		assert.True(t, status.Sealed)
+
+		err = process.Reset()
+		require.NoError(t, err)
+		assert.Equal(t, 0, process.Status().Progress)
+		assert.Equal(t, OperatorCollecting, process.State())
+	})
+
+	t.Run("should complete when threshold is reached", func(t *testing.T) {
+		_, err := process.Submit("key-1")
+		require.NoError(t, err)
+		status, err := process.Submit("key-2")
+		require.NoError(t, err)
+		assert.False(t, status.Sealed)
+		assert.Equal(t, OperatorCompleted, process.State())
+
+		_, err = process.Submit("key-3")
+		assert.EqualError(t, err, "unseal is completed")
+	})
+
+	t.Run("unsealed vault should start completed", func(t *testing.T) {
+		process, err := operator.Unseal()
+		require.NoError(t, err)
+		assert.Equal(t, OperatorCompleted, process.State())
+	})
+}
+
+func TestOperatorGenerateRoot(t *testing.T) {
+	vault := &fakeOperatorVault{rootToken: "s.5kuqQkrw3G8Yq1ub6mbCnmpc"}
+	operator, closeServer := newOperator(t, vault)
+	defer closeServer()
+
+	process, err := operator.GenerateRoot("")
+	require.NoError(t, err)
+	assert.Equal(t, OperatorCollecting, process.State())
+	assert.Len(t, process.Otp(), len(vault.rootToken))
+	assert.Equal(t, vault.otp, process.Otp())
+
+	t.Run("root token is not available before completion", func(t *testing.T) {
+		status, err := process.Submit("key-1")
+		require.NoError(t, err)
+		assert.Equal(t, 1, status.Progress)
+
+		_, err = process.RootToken()
+		assert.Error(t, err)
+	})
+
+	t.Run("should decode root token with otp", func(t *testing.T) {
+		status, err := process.Submit("key-2")
+		require.NoError(t, err)
+		assert.True(t, status.Complete)
+		assert.Equal(t, OperatorCompleted, process.State())
+
+		token, err := process.RootToken()
+		require.NoError(t, err)
+		assert.Equal(t, vault.rootToken, token)
+	})
+
+	t.Run("cancelled process should reject key", func(t *testing.T) {
+		process, err := operator.GenerateRoot("")
+		require.NoError(t, err)
+
+		err = process.Cancel()
+		require.NoError(t, err)
+		assert.Equal(t, OperatorCancelled, process.State())
+		assert.Equal(t, []string{"generate-root"}, vault.cancelled)
+
+		_, err = process.Submit("key-1")
+		assert.EqualError(t, err, "generate root is cancelled")
+	})
+}
+
+func TestOperatorRekey(t *testing.T) {
+	vault := &fakeOperatorVault{newKeys: []string{"new-1", "new-2", "new-3"}}
+	operator, closeServer := newOperator(t, vault)
+	defer closeServer()
+
+	process, err := operator.Rekey(RekeyOptions{SecretShares: 3, SecretThreshold: 2, RequireVerification: true})
+	require.NoError(t, err)
+	assert.Equal(t, OperatorCollecting, process.State())
+	assert.True(t, process.Status().VerificationRequired)
+
+	t.Run("should move to verification with new keys", func(t *testing.T) {
+		_, err := process.Submit("key-1")
+		require.NoError(t, err)
+		assert.Nil(t, process.Keys())
+
+		status, err := process.Submit("key-2")
+		require.NoError(t, err)
+		assert.True(t, status.Complete)
+		assert.Equal(t, OperatorVerifying, process.State())
+		assert.Equal(t, vault.newKeys, process.Keys())
+	})
+
+	t.Run("should complete after new keys are verified", func(t *testing.T) {
+		status, err := process.Submit("new-1")
+		require.NoError(t, err)
+		assert.Equal(t, 1, status.Progress)
+		assert.Equal(t, OperatorVerifying, process.State())
+
+		status, err = process.Submit("new-2")
+		require.NoError(t, err)
+		assert.True(t, status.Complete)
+		assert.Equal(t, OperatorCompleted, process.State())
+		assert.Equal(t, vault.newKeys, process.Keys())
+	})
+
+	t.Run("cancel should discard rekey attempt", func(t *testing.T) {
+		process, err := operator.Rekey(RekeyOptions{SecretShares: 3, SecretThreshold: 2})
+		require.NoError(t, err)
+
+		err = process.Cancel()
+		require.NoError(t, err)
+		assert.Equal(t, OperatorCancelled, process.State())
+		assert.Equal(t, []string{"rekey"}, vault.cancelled)
+	})
+}
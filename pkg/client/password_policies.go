@@ -0,0 +1,90 @@
+package client
+
+import (
+	"fmt"
+	"github.com/hashicorp/vault/api"
+	"github.com/jasoet/vault-client/pkg/util"
+)
+
+type passwordPolicyEngine struct {
+	vaultClient *api.Client
+}
+
+// Create validate the policy locally before writing it
+func (p passwordPolicyEngine) Create(name string, policy PasswordPolicy) (err error) {
+	err = policy.Validate()
+	if err != nil {
+		return
+	}
+
+	payload := map[string]interface{}{
+		"policy": policy.HCL(),
+	}
+	_, err = p.vaultClient.Logical().Write(fmt.Sprintf("sys/policies/password/%v", name), payload)
+	return
+}
+
+func (p passwordPolicyEngine) Read(name string) (policy *PasswordPolicy, err error) {
+	result, err := p.vaultClient.Logical().Read(fmt.Sprintf("sys/policies/password/%v", name))
+	if err != nil {
+		return
+	}
+
+	if result == nil {
+		err = fmt.Errorf("%v is not found", name)
+		return
+	}
+
+	parsed, err := ParsePasswordPolicy(fmt.Sprint(result.Data["policy"]))
+	if err != nil {
+		return
+	}
+	return &parsed, nil
+}
+
+func (p passwordPolicyEngine) Delete(name string) (err error) {
+	_, err = p.vaultClient.Logical().Delete(fmt.Sprintf("sys/policies/password/%v", name))
+	return
+}
+
+func (p passwordPolicyEngine) List() (list []string, err error) {
+	result, err := p.vaultClient.Logical().List("sys/policies/password")
+	if err != nil || result == nil {
+		return []string{}, nil
+	}
+
+	if val, ok := result.Data["keys"]; ok {
+		list = util.ToArrStr(val.([]interface{}))
+	}
+	return
+}
+
+func (p passwordPolicyEngine) Generate(name string) (password string, err error) {
+	result, err := p.vaultClient.Logical().Read(fmt.Sprintf("sys/policies/password/%v/generate", name))
+	if err != nil {
+		return
+	}
+
+	if result == nil {
+		err = fmt.Errorf("%v is not found", name)
+		return
+	}
+
+	password = fmt.Sprint(result.Data["password"])
+	return
+}
+
+func DefaultPasswordPolicies() (policies PasswordPolicies, err error) {
+	vaultClient, err := api.NewClient(api.DefaultConfig())
+	if err != nil {
+		return
+	}
+
+	policies = &passwordPolicyEngine{vaultClient: vaultClient}
+	return
+}
+
+func NewPasswordPolicies(vaultClient *api.Client) (policies PasswordPolicies, err error) {
+	policies = &passwordPolicyEngine{vaultClient: vaultClient}
+	return
+}
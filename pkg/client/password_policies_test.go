@@ -0,0 +1,80 @@
+// +build integration
+
+package client_test
+
+import (
+	"github.com/hashicorp/vault/api"
+	. "github.com/jasoet/vault-client/pkg/client"
+	"github.com/stretchr/testify/assert"
+	"os"
+	"strings"
+	"testing"
+)
+
+type passwordPoliciesTestCtx struct {
+	vaultClient *api.Client
+}
+
+func (ctx *passwordPoliciesTestCtx) setup(t *testing.T) {
+	config := &api.Config{Address: os.Getenv("TEST_VAULT_ADDR")}
+	client, err := api.NewClient(config)
+	assert.Nil(t, err)
+
+	client.SetToken(os.Getenv("TEST_VAULT_TOKEN"))
+
+	ctx.vaultClient = client
+}
+
+func TestPasswordPolicies(t *testing.T) {
+	ctx := new(passwordPoliciesTestCtx)
+	ctx.setup(t)
+
+	engine, err := NewPasswordPolicies(ctx.vaultClient)
+	assert.Nil(t, err)
+	assert.NotNil(t, engine)
+
+	name := "database-password"
+	policy, err := NewPasswordPolicy(24).WithLowercase(2).WithUppercase(2).WithDigits(2).WithCharset("-_", 1).Build()
+	assert.Nil(t, err)
+
+	t.Run("should create and read policy", func(t *testing.T) {
+		err := engine.Create(name, policy)
+		assert.Nil(t, err)
+
+		result, err := engine.Read(name)
+		assert.Nil(t, err)
+		assert.Equal(t, policy, *result)
+
+		list, err := engine.List()
+		assert.Nil(t, err)
+		assert.Contains(t, list, name)
+	})
+
+	t.Run("invalid policy should not be submitted", func(t *testing.T) {
+		err := engine.Create("invalid-password", PasswordPolicy{Length: 2})
+		assert.NotNil(t, err)
+
+		result, err := engine.Read("invalid-password")
+		assert.NotNil(t, err)
+		assert.Nil(t, result)
+	})
+
+	t.Run("generated password should follow policy", func(t *testing.T) {
+		password, err := engine.Generate(name)
+		assert.Nil(t, err)
+		assert.Len(t, password, 24)
+		assert.True(t, strings.ContainsAny(password, LowercaseCharset))
+		assert.True(t, strings.ContainsAny(password, UppercaseCharset))
+		assert.True(t, strings.ContainsAny(password, DigitCharset))
+		assert.True(t, strings.ContainsAny(password, "-_"))
+	})
+
+	t.Run("cannot fetch deleted policy", func(t *testing.T) {
+		err := engine.Delete(name)
+		assert.Nil(t, err)
+
+		result, err := engine.Read(name)
+		assert.NotNil(t, err)
+		assert.Nil(t, result)
+	})
+}
@@ -0,0 +1,153 @@
+package client
+
+import (
+	"fmt"
+	"github.com/hashicorp/hcl"
+	"strconv"
+	"strings"
+)
+
+const (
+	LowercaseCharset = "abcdefghijklmnopqrstuvwxyz"
+	UppercaseCharset = "ABCDEFGHIJKLMNOPQRSTUVWXYZ"
+	DigitCharset     = "0123456789"
+	SymbolCharset    = "!@#$%^&*-_=+?"
+)
+
+const (
+	minPasswordLength = 4
+	maxPasswordLength = 100
+)
+
+// PasswordRule require at least MinChars characters of Charset, characters of all rules form the password charset
+type PasswordRule struct {
+	Charset  string `json:"charset"`
+	MinChars int    `json:"min-chars"`
+}
+
+// PasswordPolicy is the typed form of Vault password policy HCL, use NewPasswordPolicy to build one
+type PasswordPolicy struct {
+	Length int            `json:"length"`
+	Rules  []PasswordRule `json:"rules"`
+}
+
+// HCL return policy in the format accepted by `sys/policies/password`
+func (p PasswordPolicy) HCL() string {
+	builder := strings.Builder{}
+	builder.WriteString(fmt.Sprintf("length = %v\n", p.Length))
+	for _, rule := range p.Rules {
+		builder.WriteString("\nrule \"charset\" {\n")
+		builder.WriteString(fmt.Sprintf("  charset = %v\n", strconv.Quote(rule.Charset)))
+		if rule.MinChars > 0 {
+			builder.WriteString(fmt.Sprintf("  min-chars = %v\n", rule.MinChars))
+		}
+		builder.WriteString("}\n")
+	}
+	return builder.String()
+}
+
+// Validate check the policy the same way Vault does, so invalid policies are caught before they are submitted
+func (p PasswordPolicy) Validate() error {
+	if p.Length < minPasswordLength || p.Length > maxPasswordLength {
+		return fmt.Errorf("password length must be between %v and %v", minPasswordLength, maxPasswordLength)
+	}
+
+	if len(p.Rules) == 0 {
+		return fmt.Errorf("password policy must have at least one charset rule")
+	}
+
+	minChars := 0
+	for index, rule := range p.Rules {
+		if rule.Charset == "" {
+			return fmt.Errorf("charset of rule %v is empty", index)
+		}
+		if rule.MinChars < 0 {
+			return fmt.Errorf("min-chars of rule %v must not be negative", index)
+		}
+		minChars += rule.MinChars
+	}
+
+	if minChars > p.Length {
+		return fmt.Errorf("sum of min-chars (%v) is greater than password length (%v)", minChars, p.Length)
+	}
+	return nil
+}
+
+// ParsePasswordPolicy parse Vault password policy HCL
+func ParsePasswordPolicy(policy string) (result PasswordPolicy, err error) {
+	parsed := struct {
+		Length int `hcl:"length"`
+		Rules  []struct {
+			Type     string `hcl:",key"`
+			Charset  string `hcl:"charset"`
+			MinChars int    `hcl:"min-chars"`
+		} `hcl:"rule"`
+	}{}
+
+	err = hcl.Decode(&parsed, policy)
+	if err != nil {
+		return
+	}
+
+	result.Length = parsed.Length
+	for _, rule := range parsed.Rules {
+		if rule.Type != "charset" {
+			err = fmt.Errorf("unknown password rule type %v", rule.Type)
+			return
+		}
+		result.Rules = append(result.Rules, PasswordRule{Charset: rule.Charset, MinChars: rule.MinChars})
+	}
+	return
+}
+
+// ValidatePasswordPolicy parse and validate password policy HCL
+func ValidatePasswordPolicy(policy string) error {
+	parsed, err := ParsePasswordPolicy(policy)
+	if err != nil {
+		return err
+	}
+	return parsed.Validate()
+}
+
+// PasswordPolicyBuilder build PasswordPolicy from charset rules
+type PasswordPolicyBuilder struct {
+	policy PasswordPolicy
+}
+
+func NewPasswordPolicy(length int) *PasswordPolicyBuilder {
+	return &PasswordPolicyBuilder{policy: PasswordPolicy{Length: length}}
+}
+
+// WithCharset add charset rule, zero minChars only add the characters to the password charset
+func (b *PasswordPolicyBuilder) WithCharset(charset string, minChars int) *PasswordPolicyBuilder {
+	b.policy.Rules = append(b.policy.Rules, PasswordRule{Charset: charset, MinChars: minChars})
+	return b
+}
+
+func (b *PasswordPolicyBuilder) WithLowercase(minChars int) *PasswordPolicyBuilder {
+	return b.WithCharset(LowercaseCharset, minChars)
+}
+
+func (b *PasswordPolicyBuilder) WithUppercase(minChars int) *PasswordPolicyBuilder {
+	return b.WithCharset(UppercaseCharset, minChars)
+}
+
+func (b *PasswordPolicyBuilder) WithDigits(minChars int) *PasswordPolicyBuilder {
+	return b.WithCharset(DigitCharset, minChars)
+}
+
+func (b *PasswordPolicyBuilder) WithSymbols(minChars int) *PasswordPolicyBuilder {
+	return b.WithCharset(SymbolCharset, minChars)
+}
+
+// Build return validated PasswordPolicy
+func (b *PasswordPolicyBuilder) Build() (policy PasswordPolicy, err error) {
+	err = b.policy.Validate()
+	if err != nil {
+		return
+	}
+
+	policy = b.policy
+	policy.Rules = append([]PasswordRule{}, b.policy.Rules...)
+	return
+}
@@ -0,0 +1,73 @@
+package client_test
+
+import (
+	. "github.com/jasoet/vault-client/pkg/client"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestPasswordPolicy(t *testing.T) {
+	t.Run("builder should produce policy accepted by parser", func(t *testing.T) {
+		policy, err := NewPasswordPolicy(24).
+			WithLowercase(2).
+			WithUppercase(2).
+			WithDigits(2).
+			WithCharset(`!"\`, 1).
+			Build()
+		require.NoError(t, err)
+
+		parsed, err := ParsePasswordPolicy(policy.HCL())
+		require.NoError(t, err)
+		assert.Equal(t, policy, parsed)
+		assert.Equal(t, `!"\`, parsed.Rules[3].Charset)
+	})
+
+	t.Run("hcl should follow vault format", func(t *testing.T) {
+		policy := PasswordPolicy{Length: 20, Rules: []PasswordRule{{Charset: "abc", MinChars: 1}, {Charset: "123"}}}
+		expected := "length = 20\n\nrule \"charset\" {\n  charset = \"abc\"\n  min-chars = 1\n}\n\nrule \"charset\" {\n  charset = \"123\"\n}\n"
+		assert.Equal(t, expected, policy.HCL())
+	})
+
+	t.Run("should parse vault documented policy", func(t *testing.T) {
+		policy := `
+length = 20
+
+rule "charset" {
+  charset = "abcdefghijklmnopqrstuvwxyz"
+  min-chars = 1
+}
+
+rule "charset" {
+  charset = "0123456789"
+  min-chars = 1
+}
+`
+		parsed, err := ParsePasswordPolicy(policy)
+		require.NoError(t, err)
+		assert.Equal(t, 20, parsed.Length)
+		assert.Equal(t, []PasswordRule{{Charset: LowercaseCharset, MinChars: 1}, {Charset: DigitCharset, MinChars: 1}}, parsed.Rules)
+		assert.NoError(t, ValidatePasswordPolicy(policy))
+	})
+
+	t.Run("validator should reject invalid policy", func(t *testing.T) {
+		invalid := map[string]string{
+			"too short":          "length = 3\nrule \"charset\" {\n  charset = \"abc\"\n}",
+			"too long":           "length = 101\nrule \"charset\" {\n  charset = \"abc\"\n}",
+			"without rule":       "length = 20",
+			"empty charset":      "length = 20\nrule \"charset\" {\n  charset = \"\"\n}",
+			"negative min chars": "length = 20\nrule \"charset\" {\n  charset = \"abc\"\n  min-chars = -1\n}",
+			"min chars overflow": "length = 4\nrule \"charset\" {\n  charset = \"abc\"\n  min-chars = 3\n}\nrule \"charset\" {\n  charset = \"123\"\n  min-chars = 2\n}",
+			"unknown rule":       "length = 20\nrule \"other\" {\n  charset = \"abc\"\n}",
+			"invalid hcl":        "length = ",
+		}
+		for name, policy := range invalid {
+			assert.Error(t, ValidatePasswordPolicy(policy), name)
+		}
+	})
+
+	t.Run("builder should reject invalid policy", func(t *testing.T) {
+		_, err := NewPasswordPolicy(8).WithLowercase(5).WithDigits(5).Build()
+		assert.EqualError(t, err, "sum of min-chars (10) is greater than password length (8)")
+	})
+}
@@ -0,0 +1,201 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"github.com/hashicorp/vault/api"
+	"github.com/jasoet/vault-client/pkg/util"
+	"io"
+	"io/ioutil"
+	"net/http"
+)
+
+type raftEngine struct {
+	vaultClient *api.Client
+	// httpClient stream snapshots without client timeout, snapshots are only bounded by the caller context
+	httpClient *http.Client
+}
+
+// Snapshot stream snapshot of the integrated storage into writer until ctx is done
+func (r raftEngine) Snapshot(ctx context.Context, writer io.Writer) (err error) {
+	response, err := r.stream(ctx, "GET", "/v1/sys/storage/raft/snapshot", nil)
+	if err != nil {
+		return
+	}
+	defer response.Body.Close()
+
+	_, err = io.Copy(writer, response.Body)
+	return
+}
+
+// Restore stream snapshot from reader without buffering it, force restore snapshot taken from a cluster
+// with different unseal keys
+func (r raftEngine) Restore(ctx context.Context, reader io.Reader, force bool) (err error) {
+	path := "/v1/sys/storage/raft/snapshot"
+	if force {
+		path = "/v1/sys/storage/raft/snapshot-force"
+	}
+
+	response, err := r.stream(ctx, "POST", path, reader)
+	if err != nil {
+		return
+	}
+	return response.Body.Close()
+}
+
+// stream send request with token and headers of vaultClient through httpClient, body is sent as is.
+// vaultClient.RawRequest can not be used because it read the whole body for retries and apply its client timeout
+func (r raftEngine) stream(ctx context.Context, method string, path string, body io.Reader) (response *http.Response, err error) {
+	request, err := r.vaultClient.NewRequest(method, path).ToHTTP()
+	if err != nil {
+		return
+	}
+
+	request = request.WithContext(ctx)
+	if body != nil {
+		// zero ContentLength with non nil Body is sent chunked
+		request.Body = ioutil.NopCloser(body)
+		request.ContentLength = 0
+	}
+
+	response, err = r.httpClient.Do(request)
+	if err != nil {
+		return
+	}
+
+	if response.StatusCode < 200 || response.StatusCode >= 300 {
+		defer response.Body.Close()
+		err = (&api.Response{Response: response}).Error()
+		if err == nil {
+			err = fmt.Errorf("unexpected status %v from %v %v", response.StatusCode, method, path)
+		}
+		return nil, err
+	}
+	return
+}
+
+func (r raftEngine) Peers() (peers []RaftPeer, err error) {
+	result, err := r.vaultClient.Logical().Read("sys/storage/raft/configuration")
+	if err != nil {
+		return
+	}
+
+	if result == nil {
+		err = fmt.Errorf("raft configuration is not found")
+		return
+	}
+
+	config, ok := result.Data["config"].(map[string]interface{})
+	if !ok {
+		err = fmt.Errorf("raft configuration is not valid")
+		return
+	}
+
+	servers := struct {
+		Servers []RaftPeer `json:"servers"`
+	}{}
+	err = util.MapToStruct(config, &servers)
+	if err != nil {
+		return
+	}
+	return servers.Servers, nil
+}
+
+func (r raftEngine) RemovePeer(nodeId string) (err error) {
+	payload := map[string]interface{}{
+		"server_id": nodeId,
+	}
+	_, err = r.vaultClient.Logical().Write("sys/storage/raft/remove-peer", payload)
+	return
+}
+
+func (r raftEngine) AutopilotState() (state *AutopilotState, err error) {
+	result, err := r.vaultClient.Logical().Read("sys/storage/raft/autopilot/state")
+	if err != nil {
+		return
+	}
+
+	if result == nil {
+		err = fmt.Errorf("autopilot state is not found")
+		return
+	}
+
+	state = new(AutopilotState)
+	err = util.MapToStruct(result.Data, state)
+	return
+}
+
+func (r raftEngine) AutopilotConfig() (config *AutopilotConfig, err error) {
+	result, err := r.vaultClient.Logical().Read("sys/storage/raft/autopilot/configuration")
+	if err != nil {
+		return
+	}
+
+	if result == nil {
+		err = fmt.Errorf("autopilot configuration is not found")
+		return
+	}
+
+	config = new(AutopilotConfig)
+	err = util.MapToStruct(result.Data, config)
+	return
+}
+
+func (r raftEngine) ConfigureAutopilot(config AutopilotConfig) (err error) {
+	payload, err := util.StructToMapE(config)
+	if err != nil {
+		return
+	}
+
+	_, err = r.vaultClient.Logical().Write("sys/storage/raft/autopilot/configuration", payload)
+	return
+}
+
+func DefaultRaft() (raft Raft, err error) {
+	return NewRaftWithConfig(api.DefaultConfig())
+}
+
+// NewRaft stream snapshots with TLS and proxy settings read from environment like api.DefaultConfig because
+// api.Client does not expose its http client. Settings configured in code on vaultClient are not used by
+// Snapshot and Restore, use NewRaftWithConfig or NewRaftWithHttpClient for such client
+func NewRaft(vaultClient *api.Client) (raft Raft, err error) {
+	config := api.DefaultConfig()
+	if config.Error != nil {
+		return nil, config.Error
+	}
+
+	return NewRaftWithHttpClient(vaultClient, config.HttpClient)
+}
+
+// NewRaftWithConfig create the vault client and the snapshot streaming client from the same config,
+// so Snapshot and Restore use its TLS and proxy settings
+func NewRaftWithConfig(config *api.Config) (raft Raft, err error) {
+	if config == nil {
+		config = api.DefaultConfig()
+	}
+
+	vaultClient, err := api.NewClient(config)
+	if err != nil {
+		return
+	}
+
+	// api.NewClient fill config.HttpClient when it is not set
+	return NewRaftWithHttpClient(vaultClient, config.HttpClient)
+}
+
+// NewRaftWithHttpClient stream snapshots through a copy of httpClient without its timeout
+func NewRaftWithHttpClient(vaultClient *api.Client, httpClient *http.Client) (raft Raft, err error) {
+	if httpClient == nil {
+		err = fmt.Errorf("http client is required to stream snapshots")
+		return
+	}
+
+	raft = &raftEngine{vaultClient: vaultClient, httpClient: streamingClient(httpClient)}
+	return
+}
+
+func streamingClient(httpClient *http.Client) *http.Client {
+	client := *httpClient
+	client.Timeout = 0
+	return &client
+}
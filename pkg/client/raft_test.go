@@ -0,0 +1,245 @@
+package client_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"github.com/hashicorp/vault/api"
+	. "github.com/jasoet/vault-client/pkg/client"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+// fakeRaftVault serve integrated storage endpoints, restored snapshots are recorded by path
+type fakeRaftVault struct {
+	snapshot []byte
+	restored map[string][]byte
+	removed  []string
+	config   map[string]interface{}
+}
+
+func (f *fakeRaftVault) handler() http.Handler {
+	write := func(w http.ResponseWriter, data interface{}) {
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"data": data})
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/v1/sys/storage/raft/snapshot", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == "GET" {
+			_, _ = w.Write(f.snapshot)
+			return
+		}
+		f.restored[r.URL.Path], _ = ioutil.ReadAll(r.Body)
+		w.WriteHeader(http.StatusNoContent)
+	})
+	mux.HandleFunc("/v1/sys/storage/raft/snapshot-force", func(w http.ResponseWriter, r *http.Request) {
+		f.restored[r.URL.Path], _ = ioutil.ReadAll(r.Body)
+		w.WriteHeader(http.StatusNoContent)
+	})
+	mux.HandleFunc("/v1/sys/storage/raft/configuration", func(w http.ResponseWriter, r *http.Request) {
+		write(w, map[string]interface{}{
+			"index": 42,
+			"config": map[string]interface{}{
+				"servers": []map[string]interface{}{
+					{"node_id": "node-1", "address": "10.0.0.1:8201", "leader": true, "protocol_version": "3", "voter": true},
+					{"node_id": "node-2", "address": "10.0.0.2:8201", "leader": false, "protocol_version": "3", "voter": false},
+				},
+			},
+		})
+	})
+	mux.HandleFunc("/v1/sys/storage/raft/remove-peer", func(w http.ResponseWriter, r *http.Request) {
+		body := map[string]string{}
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		f.removed = append(f.removed, body["server_id"])
+		w.WriteHeader(http.StatusNoContent)
+	})
+	mux.HandleFunc("/v1/sys/storage/raft/autopilot/state", func(w http.ResponseWriter, r *http.Request) {
+		write(w, map[string]interface{}{
+			"healthy":           true,
+			"failure_tolerance": 1,
+			"leader":            "node-1",
+			"voters":            []string{"node-1", "node-2", "node-3"},
+			"servers": map[string]interface{}{
+				"node-1": map[string]interface{}{
+					"id": "node-1", "name": "node-1", "address": "10.0.0.1:8201", "node_status": "alive", "status": "leader",
+					"healthy": true, "last_contact": "0s", "last_term": 3, "last_index": 460, "stable_since": "2021-03-19T20:14:11.831678-04:00",
+				},
+			},
+		})
+	})
+	mux.HandleFunc("/v1/sys/storage/raft/autopilot/configuration", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == "GET" {
+			write(w, f.config)
+			return
+		}
+		f.config = map[string]interface{}{}
+		_ = json.NewDecoder(r.Body).Decode(&f.config)
+		w.WriteHeader(http.StatusNoContent)
+	})
+	return mux
+}
+
+func TestRaft(t *testing.T) {
+	vault := &fakeRaftVault{snapshot: []byte("raft-snapshot-content"), restored: map[string][]byte{}}
+	server := httptest.NewServer(vault.handler())
+	defer server.Close()
+
+	vaultClient, err := api.NewClient(&api.Config{Address: server.URL})
+	require.NoError(t, err)
+
+	raft, err := NewRaft(vaultClient)
+	require.NoError(t, err)
+
+	t.Run("should stream snapshot to writer", func(t *testing.T) {
+		buffer := bytes.Buffer{}
+		err := raft.Snapshot(context.Background(), &buffer)
+		require.NoError(t, err)
+		assert.Equal(t, vault.snapshot, buffer.Bytes())
+	})
+
+	t.Run("should restore snapshot with and without force", func(t *testing.T) {
+		err := raft.Restore(context.Background(), bytes.NewReader([]byte("restore")), false)
+		require.NoError(t, err)
+		assert.Equal(t, []byte("restore"), vault.restored["/v1/sys/storage/raft/snapshot"])
+
+		err = raft.Restore(context.Background(), bytes.NewReader([]byte("force-restore")), true)
+		require.NoError(t, err)
+		assert.Equal(t, []byte("force-restore"), vault.restored["/v1/sys/storage/raft/snapshot-force"])
+	})
+
+	t.Run("should list and remove peers", func(t *testing.T) {
+		peers, err := raft.Peers()
+		require.NoError(t, err)
+		assert.Equal(t, []RaftPeer{
+			{NodeId: "node-1", Address: "10.0.0.1:8201", Leader: true, ProtocolVersion: "3", Voter: true},
+			{NodeId: "node-2", Address: "10.0.0.2:8201", ProtocolVersion: "3"},
+		}, peers)
+
+		err = raft.RemovePeer("node-2")
+		require.NoError(t, err)
+		assert.Equal(t, []string{"node-2"}, vault.removed)
+	})
+
+	t.Run("should read autopilot state", func(t *testing.T) {
+		state, err := raft.AutopilotState()
+		require.NoError(t, err)
+		assert.True(t, state.Healthy)
+		assert.Equal(t, 1, state.FailureTolerance)
+		assert.Len(t, state.Voters, 3)
+
+		leader := state.Servers["node-1"]
+		assert.Equal(t, "leader", leader.Status)
+		assert.Equal(t, int64(460), leader.LastIndex)
+		assert.NotNil(t, leader.StableSince)
+	})
+
+	t.Run("should configure and read autopilot", func(t *testing.T) {
+		err := raft.ConfigureAutopilot(AutopilotConfig{DeadServerLastContactThreshold: "10m", MinQuorum: 3})
+		require.NoError(t, err)
+		assert.Equal(t, false, vault.config["cleanup_dead_servers"])
+		assert.NotContains(t, vault.config, "max_trailing_logs")
+
+		config, err := raft.AutopilotConfig()
+		require.NoError(t, err)
+		assert.Equal(t, "10m", config.DeadServerLastContactThreshold)
+		assert.Equal(t, 3, config.MinQuorum)
+	})
+}
+
+func TestRaftSnapshotStreaming(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-Vault-Token") != "root" {
+			w.WriteHeader(http.StatusForbidden)
+			_, _ = w.Write([]byte(`{"errors":["permission denied"]}`))
+			return
+		}
+
+		if r.Method == "POST" {
+			body, _ := ioutil.ReadAll(r.Body)
+			assert.Equal(t, "snapshot", string(body))
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+
+		w.(http.Flusher).Flush()
+		select {
+		case <-time.After(100 * time.Millisecond):
+			_, _ = w.Write([]byte("slow-snapshot"))
+		case <-r.Context().Done():
+		}
+	}))
+	defer server.Close()
+
+	vaultClient, err := api.NewClient(&api.Config{Address: server.URL})
+	require.NoError(t, err)
+	vaultClient.SetToken("root")
+
+	// snapshot take longer than the client timeout, only the context bound the request
+	raft, err := NewRaftWithHttpClient(vaultClient, &http.Client{Timeout: 50 * time.Millisecond})
+	require.NoError(t, err)
+
+	t.Run("should ignore http client timeout", func(t *testing.T) {
+		buffer := bytes.Buffer{}
+		err := raft.Snapshot(context.Background(), &buffer)
+		require.NoError(t, err)
+		assert.Equal(t, "slow-snapshot", buffer.String())
+	})
+
+	t.Run("should stop when context is done", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+		defer cancel()
+
+		err := raft.Snapshot(ctx, &bytes.Buffer{})
+		assert.Error(t, err)
+	})
+
+	t.Run("should stream restore body", func(t *testing.T) {
+		reader, writer := io.Pipe()
+		go func() {
+			_, _ = writer.Write([]byte("snap"))
+			_, _ = writer.Write([]byte("shot"))
+			_ = writer.Close()
+		}()
+
+		err := raft.Restore(context.Background(), reader, false)
+		assert.NoError(t, err)
+	})
+
+	t.Run("should return vault error", func(t *testing.T) {
+		vaultClient.ClearToken()
+		defer vaultClient.SetToken("root")
+
+		err := raft.Snapshot(context.Background(), &bytes.Buffer{})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "permission denied")
+	})
+}
+
+func TestNewRaftWithConfig(t *testing.T) {
+	vault := &fakeRaftVault{snapshot: []byte("tls-snapshot"), restored: map[string][]byte{}}
+	server := httptest.NewTLSServer(vault.handler())
+	defer server.Close()
+
+	// the test server certificate is only trusted by its own client
+	raft, err := NewRaftWithConfig(&api.Config{Address: server.URL, HttpClient: server.Client()})
+	require.NoError(t, err)
+
+	t.Run("should stream snapshot with config tls settings", func(t *testing.T) {
+		buffer := bytes.Buffer{}
+		err := raft.Snapshot(context.Background(), &buffer)
+		require.NoError(t, err)
+		assert.Equal(t, vault.snapshot, buffer.Bytes())
+	})
+
+	t.Run("should restore snapshot with config tls settings", func(t *testing.T) {
+		err := raft.Restore(context.Background(), bytes.NewReader([]byte("restore")), false)
+		require.NoError(t, err)
+		assert.Equal(t, []byte("restore"), vault.restored["/v1/sys/storage/raft/snapshot"])
+	})
+}
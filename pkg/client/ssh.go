@@ -0,0 +1,206 @@
+package client
+
+import (
+	"fmt"
+	"github.com/hashicorp/vault/api"
+	"github.com/jasoet/vault-client/pkg/util"
+	"golang.org/x/crypto/ssh"
+	"strings"
+)
+
+type sshEngine struct {
+	vaultClient *api.Client
+	path        string
+}
+
+func (s sshEngine) Path() string {
+	return s.path
+}
+
+func (s sshEngine) Enable() (err error) {
+	data := map[string]interface{}{"type": "ssh"}
+	_, err = s.vaultClient.Logical().Write(fmt.Sprintf("/sys/mounts/%v", s.path), data)
+	return
+}
+
+func (s sshEngine) Status() (status *SecretStatus, err error) {
+	result, err := s.vaultClient.Logical().Read(fmt.Sprintf("/sys/mounts/%v/tune", s.path))
+	if err != nil || result == nil {
+		return
+	}
+
+	status = new(SecretStatus)
+	err = util.MapToStruct(result.Data, status)
+
+	return
+}
+
+func (s sshEngine) ConfigureCA(config SSHCAConfig) (publicKey string, err error) {
+	payload, err := util.StructToMapE(config)
+	if err != nil {
+		return
+	}
+
+	_, err = s.vaultClient.Logical().Write(fmt.Sprintf("%v/config/ca", s.path), payload)
+	if err != nil {
+		return
+	}
+
+	return s.ReadCAPublicKey()
+}
+
+func (s sshEngine) ReadCAPublicKey() (publicKey string, err error) {
+	result, err := s.vaultClient.Logical().Read(fmt.Sprintf("%v/config/ca", s.path))
+	if err != nil {
+		return
+	}
+
+	if result == nil {
+		err = fmt.Errorf("%v ca is not configured", s.path)
+		return
+	}
+
+	publicKey = fmt.Sprint(result.Data["public_key"])
+	return
+}
+
+func (s sshEngine) DeleteCA() (err error) {
+	_, err = s.vaultClient.Logical().Delete(fmt.Sprintf("%v/config/ca", s.path))
+	return
+}
+
+func (s sshEngine) CreateRole(name string, role SSHRole) (err error) {
+	payload, err := util.StructToMapE(role)
+	if err != nil {
+		return
+	}
+
+	_, err = s.vaultClient.Logical().Write(fmt.Sprintf("%v/roles/%v", s.path, name), payload)
+	return
+}
+
+func (s sshEngine) ReadRole(name string) (role *SSHRole, err error) {
+	result, err := s.vaultClient.Logical().Read(fmt.Sprintf("%v/roles/%v", s.path, name))
+	if err != nil {
+		return
+	}
+
+	if result == nil {
+		err = fmt.Errorf("%v is not found", name)
+		return
+	}
+
+	role = new(SSHRole)
+	err = util.MapToStruct(result.Data, role)
+	return
+}
+
+func (s sshEngine) DeleteRole(name string) (err error) {
+	_, err = s.vaultClient.Logical().Delete(fmt.Sprintf("%v/roles/%v", s.path, name))
+	return
+}
+
+func (s sshEngine) ListRole() (list []string, err error) {
+	result, err := s.vaultClient.Logical().List(fmt.Sprintf("%v/roles", s.path))
+	if err != nil || result == nil {
+		return []string{}, nil
+	}
+
+	if val, ok := result.Data["keys"]; ok {
+		list = util.ToArrStr(val.([]interface{}))
+	}
+	return
+}
+
+// SignPublicKey sign authorized_keys formatted publicKey, ttl in seconds, zero ttl use role ttl
+func (s sshEngine) SignPublicKey(roleName string, publicKey string, principals []string, ttl int) (certificate *ssh.Certificate, err error) {
+	payload := map[string]interface{}{
+		"public_key": publicKey,
+	}
+	if len(principals) > 0 {
+		payload["valid_principals"] = strings.Join(principals, ",")
+	}
+	if ttl > 0 {
+		payload["ttl"] = ttl
+	}
+
+	result, err := s.vaultClient.Logical().Write(fmt.Sprintf("%v/sign/%v", s.path, roleName), payload)
+	if err != nil {
+		return
+	}
+
+	if result == nil {
+		err = fmt.Errorf("%v is not found", roleName)
+		return
+	}
+
+	return ParseSSHCertificate(fmt.Sprint(result.Data["signed_key"]))
+}
+
+func (s sshEngine) GenerateOTP(roleName string, ip string, username string) (creds *SSHOTPCreds, err error) {
+	payload := map[string]interface{}{
+		"ip": ip,
+	}
+	if username != "" {
+		payload["username"] = username
+	}
+
+	result, err := s.vaultClient.Logical().Write(fmt.Sprintf("%v/creds/%v", s.path, roleName), payload)
+	if err != nil {
+		return
+	}
+
+	if result == nil {
+		err = fmt.Errorf("%v is not found", roleName)
+		return
+	}
+
+	creds = new(SSHOTPCreds)
+	err = util.MapToStruct(result.Data, creds)
+	if err != nil {
+		return
+	}
+
+	creds.LeaseId = result.LeaseID
+	creds.LeaseDuration = result.LeaseDuration
+	return
+}
+
+func (s sshEngine) VerifyOTP(otp string) (verification *SSHOTPVerification, err error) {
+	payload := map[string]interface{}{
+		"otp": otp,
+	}
+	result, err := s.vaultClient.Logical().Write(fmt.Sprintf("%v/verify", s.path), payload)
+	if err != nil {
+		return
+	}
+
+	if result == nil {
+		err = fmt.Errorf("otp is not valid")
+		return
+	}
+
+	verification = new(SSHOTPVerification)
+	err = util.MapToStruct(result.Data, verification)
+	return
+}
+
+func DefaultSSH() (SSH, error) {
+	vaultClient, err := api.NewClient(api.DefaultConfig())
+	if err != nil {
+		return nil, err
+	}
+	return &sshEngine{vaultClient: vaultClient, path: "ssh"}, nil
+}
+
+func NewSSH(vaultClient *api.Client, path string) (SSH, error) {
+	return &sshEngine{vaultClient: vaultClient, path: path}, nil
+}
+
+func NewSSHWithPath(path string) (SSH, error) {
+	vaultClient, err := api.NewClient(api.DefaultConfig())
+	if err != nil {
+		return nil, err
+	}
+	return &sshEngine{vaultClient: vaultClient, path: path}, nil
+}
@@ -0,0 +1,40 @@
+package client
+
+import (
+	"fmt"
+	"golang.org/x/crypto/ssh"
+	"io/ioutil"
+)
+
+// ParseSSHCertificate parse certificate in authorized_keys format, as returned by SSH sign endpoint
+func ParseSSHCertificate(signedKey string) (certificate *ssh.Certificate, err error) {
+	publicKey, _, _, _, err := ssh.ParseAuthorizedKey([]byte(signedKey))
+	if err != nil {
+		return
+	}
+
+	certificate, ok := publicKey.(*ssh.Certificate)
+	if !ok {
+		err = fmt.Errorf("signed key is not ssh certificate")
+	}
+	return
+}
+
+// WriteSSHCertificate write certificate next to private key following OpenSSH naming, `id_rsa` certificate is
+// written to `id_rsa-cert.pub`, so ssh clients pick it up automatically
+func WriteSSHCertificate(privateKeyPath string, certificate *ssh.Certificate) (certificatePath string, err error) {
+	certificatePath = fmt.Sprintf("%v-cert.pub", privateKeyPath)
+	err = ioutil.WriteFile(certificatePath, ssh.MarshalAuthorizedKey(certificate), 0644)
+	return
+}
+
+// NewSSHCertSigner combine PEM encoded private key with its signed certificate into signer for
+// `golang.org/x/crypto/ssh` client, e.g. `ssh.ClientConfig{Auth: []ssh.AuthMethod{ssh.PublicKeys(signer)}}`
+func NewSSHCertSigner(privateKey []byte, certificate *ssh.Certificate) (signer ssh.Signer, err error) {
+	keySigner, err := ssh.ParsePrivateKey(privateKey)
+	if err != nil {
+		return
+	}
+
+	return ssh.NewCertSigner(certificate, keySigner)
+}
@@ -0,0 +1,77 @@
+package client_test
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	. "github.com/jasoet/vault-client/pkg/client"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/ssh"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestSSHCertificate(t *testing.T) {
+	caKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	caSigner, err := ssh.NewSignerFromKey(caKey)
+	require.NoError(t, err)
+
+	userKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	userPublicKey, err := ssh.NewPublicKey(&userKey.PublicKey)
+	require.NoError(t, err)
+	userKeyPem := pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(userKey)})
+
+	certificate := &ssh.Certificate{
+		Key:             userPublicKey,
+		Serial:          1,
+		CertType:        ssh.UserCert,
+		KeyId:           "vault-test",
+		ValidPrincipals: []string{"ubuntu", "deploy"},
+		ValidAfter:      uint64(time.Now().Unix()),
+		ValidBefore:     uint64(time.Now().Add(time.Hour).Unix()),
+	}
+	require.NoError(t, certificate.SignCert(rand.Reader, caSigner))
+	signedKey := string(ssh.MarshalAuthorizedKey(certificate))
+
+	t.Run("should parse signed key", func(t *testing.T) {
+		parsed, err := ParseSSHCertificate(signedKey)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"ubuntu", "deploy"}, parsed.ValidPrincipals)
+		assert.Equal(t, "vault-test", parsed.KeyId)
+		assert.Equal(t, caSigner.PublicKey().Marshal(), parsed.SignatureKey.Marshal())
+	})
+
+	t.Run("should reject plain public key", func(t *testing.T) {
+		parsed, err := ParseSSHCertificate(string(ssh.MarshalAuthorizedKey(userPublicKey)))
+		assert.NotNil(t, err)
+		assert.Nil(t, parsed)
+	})
+
+	t.Run("should write certificate next to private key", func(t *testing.T) {
+		dir, err := ioutil.TempDir("", "ssh-cert")
+		require.NoError(t, err)
+		defer os.RemoveAll(dir)
+
+		keyPath := filepath.Join(dir, "id_rsa")
+		certificatePath, err := WriteSSHCertificate(keyPath, certificate)
+		require.NoError(t, err)
+		assert.Equal(t, keyPath+"-cert.pub", certificatePath)
+
+		content, err := ioutil.ReadFile(certificatePath)
+		require.NoError(t, err)
+		assert.Equal(t, signedKey, string(content))
+	})
+
+	t.Run("should create signer presenting the certificate", func(t *testing.T) {
+		signer, err := NewSSHCertSigner(userKeyPem, certificate)
+		require.NoError(t, err)
+		assert.Equal(t, certificate.Marshal(), signer.PublicKey().Marshal())
+	})
+}
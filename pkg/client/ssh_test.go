@@ -0,0 +1,141 @@
+// +build integration
+
+package client_test
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"github.com/hashicorp/vault/api"
+	. "github.com/jasoet/vault-client/pkg/client"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/crypto/ssh"
+	"os"
+	"testing"
+)
+
+type sshTestCtx struct {
+	vaultClient *api.Client
+}
+
+func (ctx *sshTestCtx) setup(t *testing.T) {
+	config := &api.Config{Address: os.Getenv("TEST_VAULT_ADDR")}
+	client, err := api.NewClient(config)
+	assert.Nil(t, err)
+
+	client.SetToken(os.Getenv("TEST_VAULT_TOKEN"))
+
+	ctx.vaultClient = client
+}
+
+func TestSSH(t *testing.T) {
+	ctx := new(sshTestCtx)
+	ctx.setup(t)
+
+	sshEnginePath := "ssh-path"
+	engine, err := NewSSH(ctx.vaultClient, sshEnginePath)
+	assert.Nil(t, err)
+	assert.NotNil(t, engine)
+
+	_ = engine.Enable()
+
+	t.Run("status should return correct result", func(t *testing.T) {
+		status, err := engine.Status()
+		assert.Nil(t, err)
+		assert.NotNil(t, status)
+	})
+
+	t.Run("configure ca should return public key", func(t *testing.T) {
+		_ = engine.DeleteCA()
+
+		publicKey, err := engine.ConfigureCA(SSHCAConfig{GenerateSigningKey: true})
+		assert.Nil(t, err)
+		assert.NotEmpty(t, publicKey)
+
+		readPublicKey, err := engine.ReadCAPublicKey()
+		assert.Nil(t, err)
+		assert.Equal(t, publicKey, readPublicKey)
+	})
+
+	roleName := "ssh-user"
+	role := SSHRole{
+		KeyType:               SSHCertificateKey,
+		DefaultUser:           "ubuntu",
+		AllowedUsers:          "ubuntu,deploy",
+		AllowedExtensions:     "permit-pty",
+		DefaultExtensions:     map[string]string{"permit-pty": ""},
+		AllowUserCertificates: true,
+		Ttl:                   "30m",
+		MaxTtl:                "1h",
+	}
+
+	t.Run("create role should not produce error", func(t *testing.T) {
+		err = engine.CreateRole(roleName, role)
+		assert.Nil(t, err)
+	})
+
+	t.Run("fetch role should return correct values", func(t *testing.T) {
+		detail, err := engine.ReadRole(roleName)
+		assert.Nil(t, err)
+		assert.NotNil(t, detail)
+		assert.Equal(t, role.KeyType, detail.KeyType)
+		assert.Equal(t, role.DefaultUser, detail.DefaultUser)
+		assert.Equal(t, role.AllowedUsers, detail.AllowedUsers)
+		assert.True(t, detail.AllowUserCertificates)
+
+		list, err := engine.ListRole()
+		assert.Nil(t, err)
+		assert.Contains(t, list, roleName)
+	})
+
+	t.Run("sign public key should return certificate for principals", func(t *testing.T) {
+		key, err := rsa.GenerateKey(rand.Reader, 2048)
+		assert.Nil(t, err)
+		publicKey, err := ssh.NewPublicKey(&key.PublicKey)
+		assert.Nil(t, err)
+
+		certificate, err := engine.SignPublicKey(roleName, string(ssh.MarshalAuthorizedKey(publicKey)), []string{"deploy"}, 600)
+		assert.Nil(t, err)
+		assert.NotNil(t, certificate)
+		assert.Equal(t, uint32(ssh.UserCert), certificate.CertType)
+		assert.Equal(t, []string{"deploy"}, certificate.ValidPrincipals)
+		assert.Equal(t, uint64(600), certificate.ValidBefore-certificate.ValidAfter)
+		assert.Equal(t, publicKey.Marshal(), certificate.Key.Marshal())
+
+		caPublicKey, err := engine.ReadCAPublicKey()
+		assert.Nil(t, err)
+		assert.Equal(t, caPublicKey, string(ssh.MarshalAuthorizedKey(certificate.SignatureKey)))
+	})
+
+	otpRoleName := "ssh-otp"
+	t.Run("generated otp should be verified once", func(t *testing.T) {
+		err = engine.CreateRole(otpRoleName, SSHRole{KeyType: SSHOTPKey, DefaultUser: "ubuntu", CidrList: "10.0.0.0/24"})
+		assert.Nil(t, err)
+
+		creds, err := engine.GenerateOTP(otpRoleName, "10.0.0.10", "")
+		assert.Nil(t, err)
+		assert.NotNil(t, creds)
+		assert.NotEmpty(t, creds.Key)
+		assert.Equal(t, "ubuntu", creds.Username)
+		assert.Equal(t, SSHOTPKey, creds.KeyType)
+
+		verification, err := engine.VerifyOTP(creds.Key)
+		assert.Nil(t, err)
+		assert.NotNil(t, verification)
+		assert.Equal(t, "10.0.0.10", verification.Ip)
+		assert.Equal(t, otpRoleName, verification.RoleName)
+
+		verification, err = engine.VerifyOTP(creds.Key)
+		assert.NotNil(t, err)
+		assert.Nil(t, verification)
+	})
+
+	t.Run("cannot fetch deleted role", func(t *testing.T) {
+		err := engine.DeleteRole(otpRoleName)
+		assert.Nil(t, err)
+
+		detail, err := engine.ReadRole(otpRoleName)
+		assert.NotNil(t, err)
+		assert.Nil(t, detail)
+	})
+
+}
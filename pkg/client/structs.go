@@ -5,8 +5,32 @@ import "time"
 type DatabaseType string
 
 const (
-	MySQL      DatabaseType = "mysql-database-plugin"
-	PostgreSQL DatabaseType = "postgresql-database-plugin"
+	MySQL            DatabaseType = "mysql-database-plugin"
+	MySQLAurora      DatabaseType = "mysql-aurora-database-plugin"
+	MySQLRDS         DatabaseType = "mysql-rds-database-plugin"
+	MySQLLegacy      DatabaseType = "mysql-legacy-database-plugin"
+	PostgreSQL       DatabaseType = "postgresql-database-plugin"
+	Redshift         DatabaseType = "redshift-database-plugin"
+	MSSQL            DatabaseType = "mssql-database-plugin"
+	Oracle           DatabaseType = "vault-plugin-database-oracle"
+	HANA             DatabaseType = "hana-database-plugin"
+	Snowflake        DatabaseType = "snowflake-database-plugin"
+	MongoDB          DatabaseType = "mongodb-database-plugin"
+	MongoDBAtlas     DatabaseType = "mongodbatlas-database-plugin"
+	Redis            DatabaseType = "redis-database-plugin"
+	RedisElastiCache DatabaseType = "redis-elasticache-database-plugin"
+	Cassandra        DatabaseType = "cassandra-database-plugin"
+	Elasticsearch    DatabaseType = "elasticsearch-database-plugin"
+	Couchbase        DatabaseType = "couchbase-database-plugin"
+	InfluxDB         DatabaseType = "influxdb-database-plugin"
+)
+
+type CredentialType string
+
+const (
+	PasswordCredential          CredentialType = "password"
+	RSAPrivateKeyCredential     CredentialType = "rsa_private_key"
+	ClientCertificateCredential CredentialType = "client_certificate"
 )
 
 type SecretStatus struct {
@@ -17,13 +41,16 @@ type SecretStatus struct {
 }
 
 type DatabaseConfig struct {
-	Type                   DatabaseType `json:"plugin_name"`
-	ConnectionUrl          string       `json:"connection_url"`
-	Username               string       `json:"username"`
-	Password               string       `json:"password"`
-	AllowedRoles           []string     `json:"allowed_roles"`
-	RootRotationStatements []string     `json:"root_rotation_statements,omitempty"`
-	PasswordPolicy         string       `json:"password_policy,omitempty"`
+	Type                   DatabaseType          `json:"plugin_name"`
+	ConnectionUrl          string                `json:"connection_url,omitempty"`
+	Username               string                `json:"username"`
+	Password               string                `json:"password"`
+	AllowedRoles           []string              `json:"allowed_roles"`
+	RootRotationStatements []string              `json:"root_rotation_statements,omitempty"`
+	PasswordPolicy         string                `json:"password_policy,omitempty"`
+	VerifyConnection       *bool                 `json:"verify_connection,omitempty"`
+	PluginVersion          string                `json:"plugin_version,omitempty"`
+	Options                DatabasePluginOptions `json:"-"` // plugin specific fields, e.g. MySQLOptions
 }
 
 type DatabaseRole struct {
@@ -34,14 +61,41 @@ type DatabaseRole struct {
 	RevocationStatements []string `json:"revocation_statements"`
 	RollbackStatements   []string `json:"rollback_statements,omitempty"`
 	RenewStatements      []string `json:"renew_statements,omitempty"`
+
+	// CredentialType default to PasswordCredential, CredentialConfig hold its options,
+	// e.g. `key_bits` for RSAPrivateKeyCredential or `ca_cert`, `ca_private_key` for ClientCertificateCredential
+	CredentialType   CredentialType         `json:"credential_type,omitempty"`
+	CredentialConfig map[string]interface{} `json:"credential_config,omitempty"`
+}
+
+type DatabaseStaticRole struct {
+	ConnectionName     string     `json:"db_name"`
+	Username           string     `json:"username"`
+	RotationPeriod     int        `json:"rotation_period"`
+	RotationStatements []string   `json:"rotation_statements,omitempty"`
+	LastVaultRotation  *time.Time `json:"last_vault_rotation,omitempty"` // read only
+}
+
+type StaticCreds struct {
+	Username          string    `json:"username"`
+	Password          string    `json:"password"`
+	LastVaultRotation time.Time `json:"last_vault_rotation"`
+	RotationPeriod    int       `json:"rotation_period"`
+	Ttl               int       `json:"ttl"`
 }
 
+// Creds hold generated credentials, only the fields of its CredentialType are filled
 type Creds struct {
-	LeaseId       string `json:"lease_id"`
-	LeaseDuration int    `json:"lease_duration"`
-	Renewable     bool   `json:"renewable"`
-	Username      string `json:"username"`
-	Password      string `json:"password"`
+	LeaseId           string         `json:"lease_id"`
+	LeaseDuration     int            `json:"lease_duration"`
+	Renewable         bool           `json:"renewable"`
+	CredentialType    CredentialType `json:"credential_type"`
+	Username          string         `json:"username"`
+	Password          string         `json:"password,omitempty"`
+	RsaPrivateKey     string         `json:"rsa_private_key,omitempty"`
+	ClientCertificate string         `json:"client_certificate,omitempty"`
+	PrivateKey        string         `json:"private_key,omitempty"`
+	PrivateKeyType    string         `json:"private_key_type,omitempty"`
 }
 
 type LeaseDetail struct {
@@ -53,6 +107,34 @@ type LeaseDetail struct {
 	Ttl             int       `json:"ttl"`
 }
 
+// BatchOptions control how Lease batch operations are executed.
+// Zero Workers falls back to 10 concurrent requests, zero RateLimit means no limit on requests per second.
+type BatchOptions struct {
+	Workers   int     `json:"workers"`
+	RateLimit float64 `json:"rate_limit"`
+}
+
+// LeaseResult is the outcome of a single lease in a batch operation, Detail only filled by LookupAll.
+type LeaseResult struct {
+	LeaseId string       `json:"lease_id"`
+	Detail  *LeaseDetail `json:"detail,omitempty"`
+	Err     error        `json:"-"`
+}
+
+// RevokeOptions nil Sync use the Vault default which wait until the backend finished revoking the secret,
+// false Sync return before the backend revoked it
+type RevokeOptions struct {
+	Sync *bool `json:"sync,omitempty"`
+}
+
+// RevokeResult split leases found before revocation into the ones removed and the ones still alive.
+// Async revocation may report leases as Remaining while the backend is still revoking them.
+type RevokeResult struct {
+	Prefix    string   `json:"prefix"`
+	Revoked   []string `json:"revoked"`
+	Remaining []string `json:"remaining"`
+}
+
 type KVConfig struct {
 	MaxVersions        int    `json:"max_versions"`
 	CasRequired        bool   `json:"cas_required"`
@@ -69,8 +151,599 @@ type KVMetadata struct {
 type KVHistoryMetadata struct {
 	CreatedTime    time.Time             `json:"created_time"`
 	CurrentVersion int                   `json:"current_version"`
-	MaxVersion     int                   `json:"max_version"`
+	MaxVersion     int                   `json:"max_versions"`
 	OldestVersion  int                   `json:"oldest_version"`
 	UpdatedTime    *time.Time             `json:"updated_time"`
 	Versions       map[string]KVMetadata `json:"versions"`
+
+	CasRequired        bool              `json:"cas_required"`
+	DeleteVersionAfter string            `json:"delete_version_after"`
+	CustomMetadata     map[string]string `json:"custom_metadata"`
+}
+
+// KVSecretMetadata per secret settings, nil MaxVersions, CasRequired and DeleteVersionAfter keep the current setting
+// of the secret. Zero MaxVersions and "0s" DeleteVersionAfter reset the secret to the engine KVConfig.
+// CustomMetadata replace all existing custom metadata of the secret when set,
+// ClearCustomMetadata remove all of them because empty CustomMetadata is not sent
+type KVSecretMetadata struct {
+	MaxVersions         *int              `json:"max_versions,omitempty"`
+	CasRequired         *bool             `json:"cas_required,omitempty"`
+	DeleteVersionAfter  *string           `json:"delete_version_after,omitempty"` //use go duration format https://golang.org/pkg/time/#ParseDuration
+	CustomMetadata      map[string]string `json:"custom_metadata,omitempty"`
+	ClearCustomMetadata bool              `json:"-"`
+}
+
+// WrapOptions is shared by every operation returning a wrapped response, Ttl is the wrapping token ttl in seconds
+type WrapOptions struct {
+	Ttl int `json:"ttl"`
+}
+
+type WrapInfo struct {
+	Token           string    `json:"token"`
+	Accessor        string    `json:"accessor"`
+	Ttl             int       `json:"ttl"`
+	CreationTime    time.Time `json:"creation_time"`
+	CreationPath    string    `json:"creation_path"`
+	WrappedAccessor string    `json:"wrapped_accessor"`
+}
+
+type SSHKeyType string
+
+const (
+	SSHCertificateKey SSHKeyType = "ca"
+	SSHOTPKey         SSHKeyType = "otp"
+)
+
+// SSHCAConfig either generate a new signing key or import PrivateKey and PublicKey pair
+type SSHCAConfig struct {
+	GenerateSigningKey bool   `json:"generate_signing_key"`
+	PrivateKey         string `json:"private_key,omitempty"`
+	PublicKey          string `json:"public_key,omitempty"`
+}
+
+// SSHRole users, domains, extensions and cidr fields are comma separated lists
+type SSHRole struct {
+	KeyType                SSHKeyType        `json:"key_type"`
+	DefaultUser            string            `json:"default_user,omitempty"`
+	AllowedUsers           string            `json:"allowed_users,omitempty"`
+	AllowedDomains         string            `json:"allowed_domains,omitempty"`
+	AllowedExtensions      string            `json:"allowed_extensions,omitempty"`
+	DefaultExtensions      map[string]string `json:"default_extensions,omitempty"`
+	AllowedCriticalOptions string            `json:"allowed_critical_options,omitempty"`
+	AllowUserCertificates  bool              `json:"allow_user_certificates,omitempty"`
+	AllowHostCertificates  bool              `json:"allow_host_certificates,omitempty"`
+	AllowUserKeyIds        bool              `json:"allow_user_key_ids,omitempty"`
+	Ttl                    string            `json:"ttl,omitempty"`     //use go duration format https://golang.org/pkg/time/#ParseDuration
+	MaxTtl                 string            `json:"max_ttl,omitempty"` //use go duration format https://golang.org/pkg/time/#ParseDuration
+	CidrList               string            `json:"cidr_list,omitempty"`
+	ExcludeCidrList        string            `json:"exclude_cidr_list,omitempty"`
+	Port                   int               `json:"port,omitempty"`
+}
+
+type SSHOTPCreds struct {
+	LeaseId       string     `json:"lease_id"`
+	LeaseDuration int        `json:"lease_duration"`
+	Ip            string     `json:"ip"`
+	Key           string     `json:"key"`
+	KeyType       SSHKeyType `json:"key_type"`
+	Port          int        `json:"port"`
+	Username      string     `json:"username"`
+}
+
+type SSHOTPVerification struct {
+	Ip       string `json:"ip"`
+	Username string `json:"username"`
+	RoleName string `json:"role_name"`
+}
+
+// TOTPKeyConfig Exported, KeySize, Skew and QrSize are only used when the key is created
+type TOTPKeyConfig struct {
+	Issuer      string `json:"issuer,omitempty"`
+	AccountName string `json:"account_name,omitempty"`
+	Period      int    `json:"period,omitempty"`
+	Algorithm   string `json:"algorithm,omitempty"` // SHA1, SHA256 or SHA512
+	Digits      int    `json:"digits,omitempty"`
+	Skew        int    `json:"skew,omitempty"`
+	KeySize     int    `json:"key_size,omitempty"`
+	QrSize      int    `json:"qr_size,omitempty"`
+	Exported    *bool  `json:"exported,omitempty"`
+}
+
+type TOTPKey struct {
+	Barcode string `json:"barcode"` // base64 encoded PNG
+	Url     string `json:"url"`
+}
+
+// IdentityEntity Id, Aliases, group ids and times are filled by Vault and ignored on write,
+// nil Disabled keep the current state on update
+type IdentityEntity struct {
+	Id                string                `json:"id,omitempty"`
+	Name              string                `json:"name"`
+	Metadata          map[string]string     `json:"metadata,omitempty"`
+	Policies          []string              `json:"policies,omitempty"`
+	Disabled          *bool                 `json:"disabled,omitempty"`
+	Aliases           []IdentityEntityAlias `json:"aliases,omitempty"`
+	DirectGroupIds    []string              `json:"direct_group_ids,omitempty"`
+	GroupIds          []string              `json:"group_ids,omitempty"`
+	InheritedGroupIds []string              `json:"inherited_group_ids,omitempty"`
+	CreationTime      *time.Time            `json:"creation_time,omitempty"`
+	LastUpdateTime    *time.Time            `json:"last_update_time,omitempty"`
+}
+
+type IdentityEntityAlias struct {
+	Id             string            `json:"id,omitempty"`
+	Name           string            `json:"name"`
+	CanonicalId    string            `json:"canonical_id"`
+	MountAccessor  string            `json:"mount_accessor"`
+	MountPath      string            `json:"mount_path,omitempty"`
+	MountType      string            `json:"mount_type,omitempty"`
+	CustomMetadata map[string]string `json:"custom_metadata,omitempty"`
+	CreationTime   *time.Time        `json:"creation_time,omitempty"`
+	LastUpdateTime *time.Time        `json:"last_update_time,omitempty"`
+}
+
+type IdentityGroupType string
+
+const (
+	InternalGroup IdentityGroupType = "internal"
+	ExternalGroup IdentityGroupType = "external" // members are managed by group alias of an auth method, e.g. LDAP group
+)
+
+// IdentityGroup Id, ParentGroupIds, Alias and times are filled by Vault and ignored on write
+type IdentityGroup struct {
+	Id              string              `json:"id,omitempty"`
+	Name            string              `json:"name"`
+	Type            IdentityGroupType   `json:"type,omitempty"`
+	Metadata        map[string]string   `json:"metadata,omitempty"`
+	Policies        []string            `json:"policies,omitempty"`
+	MemberEntityIds []string            `json:"member_entity_ids,omitempty"`
+	MemberGroupIds  []string            `json:"member_group_ids,omitempty"`
+	ParentGroupIds  []string            `json:"parent_group_ids,omitempty"`
+	Alias           *IdentityGroupAlias `json:"alias,omitempty"`
+	CreationTime    *time.Time          `json:"creation_time,omitempty"`
+	LastUpdateTime  *time.Time          `json:"last_update_time,omitempty"`
+}
+
+type IdentityGroupAlias struct {
+	Id             string     `json:"id,omitempty"`
+	Name           string     `json:"name"`
+	CanonicalId    string     `json:"canonical_id"`
+	MountAccessor  string     `json:"mount_accessor"`
+	MountPath      string     `json:"mount_path,omitempty"`
+	MountType      string     `json:"mount_type,omitempty"`
+	CreationTime   *time.Time `json:"creation_time,omitempty"`
+	LastUpdateTime *time.Time `json:"last_update_time,omitempty"`
+}
+
+// IdentityLookup search by one of Name, Id, AliasId or AliasName with AliasMountAccessor
+type IdentityLookup struct {
+	Name               string `json:"name,omitempty"`
+	Id                 string `json:"id,omitempty"`
+	AliasId            string `json:"alias_id,omitempty"`
+	AliasName          string `json:"alias_name,omitempty"`
+	AliasMountAccessor string `json:"alias_mount_accessor,omitempty"`
+}
+
+type IdentityOIDCConfig struct {
+	Issuer string `json:"issuer"` // scheme, host and optional port, defaults to Vault api address
+}
+
+type IdentityOIDCKey struct {
+	RotationPeriod   int      `json:"rotation_period,omitempty"`  // in seconds
+	VerificationTtl  int      `json:"verification_ttl,omitempty"` // in seconds, rotated keys stay in JWKS until it passed
+	AllowedClientIds []string `json:"allowed_client_ids,omitempty"`
+	Algorithm        string   `json:"algorithm,omitempty"` // RS256, RS384, RS512, ES256, ES384, ES512 or EdDSA
+}
+
+// IdentityOIDCRole ClientId is generated by Vault and used as token audience
+type IdentityOIDCRole struct {
+	Key      string `json:"key"`
+	Template string `json:"template,omitempty"` // JSON claims template, e.g. {"groups": {{identity.entity.groups.names}}}
+	ClientId string `json:"client_id,omitempty"`
+	Ttl      int    `json:"ttl,omitempty"` // in seconds
+}
+
+type IdentityToken struct {
+	ClientId string `json:"client_id"`
+	Token    string `json:"token"`
+	Ttl      int    `json:"ttl"`
+}
+
+// AuthMount Accessor is filled by Vault and ignored on write
+type AuthMount struct {
+	Type        string          `json:"type"`
+	Description string          `json:"description,omitempty"`
+	Accessor    string          `json:"accessor,omitempty"`
+	Local       bool            `json:"local,omitempty"`
+	SealWrap    bool            `json:"seal_wrap,omitempty"`
+	Config      AuthMountConfig `json:"config"`
+}
+
+type AuthMountConfig struct {
+	DefaultLeaseTtl           int      `json:"default_lease_ttl,omitempty"`  // in seconds
+	MaxLeaseTtl               int      `json:"max_lease_ttl,omitempty"`      // in seconds
+	ListingVisibility         string   `json:"listing_visibility,omitempty"` // `unauth` or `hidden`
+	TokenType                 string   `json:"token_type,omitempty"`
+	AuditNonHmacRequestKeys   []string `json:"audit_non_hmac_request_keys,omitempty"`
+	AuditNonHmacResponseKeys  []string `json:"audit_non_hmac_response_keys,omitempty"`
+	PassthroughRequestHeaders []string `json:"passthrough_request_headers,omitempty"`
+}
+
+// AuthTokenConfig common token fields of auth method roles, embed it in the role struct
+type AuthTokenConfig struct {
+	TokenTtl             int      `json:"token_ttl,omitempty"`              // in seconds
+	TokenMaxTtl          int      `json:"token_max_ttl,omitempty"`          // in seconds
+	TokenExplicitMaxTtl  int      `json:"token_explicit_max_ttl,omitempty"` // in seconds
+	TokenPeriod          int      `json:"token_period,omitempty"`           // in seconds, periodic token when not zero
+	TokenPolicies        []string `json:"token_policies,omitempty"`
+	TokenBoundCidrs      []string `json:"token_bound_cidrs,omitempty"`
+	TokenNoDefaultPolicy bool     `json:"token_no_default_policy,omitempty"`
+	TokenNumUses         int      `json:"token_num_uses,omitempty"`
+	TokenType            string   `json:"token_type,omitempty"` // `service`, `batch` or `default`
+}
+
+type AppRoleRole struct {
+	AuthTokenConfig
+	BindSecretId       *bool    `json:"bind_secret_id,omitempty"`
+	SecretIdBoundCidrs []string `json:"secret_id_bound_cidrs,omitempty"`
+	SecretIdNumUses    int      `json:"secret_id_num_uses,omitempty"`
+	SecretIdTtl        int      `json:"secret_id_ttl,omitempty"` // in seconds
+	LocalSecretIds     bool     `json:"local_secret_ids,omitempty"`
+}
+
+type AppRoleSecretIdOptions struct {
+	Metadata        map[string]string `json:"metadata,omitempty"`
+	CidrList        []string          `json:"cidr_list,omitempty"`
+	TokenBoundCidrs []string          `json:"token_bound_cidrs,omitempty"`
+	Ttl             int               `json:"ttl,omitempty"` // in seconds
+	NumUses         int               `json:"num_uses,omitempty"`
+}
+
+type AppRoleSecretId struct {
+	SecretId         string `json:"secret_id"`
+	SecretIdAccessor string `json:"secret_id_accessor"`
+	SecretIdTtl      int    `json:"secret_id_ttl"`
+	SecretIdNumUses  int    `json:"secret_id_num_uses"`
+}
+
+type AppRoleSecretIdInfo struct {
+	SecretIdAccessor string            `json:"secret_id_accessor"`
+	Metadata         map[string]string `json:"metadata"`
+	CidrList         []string          `json:"cidr_list"`
+	TokenBoundCidrs  []string          `json:"token_bound_cidrs"`
+	SecretIdTtl      int               `json:"secret_id_ttl"`
+	SecretIdNumUses  int               `json:"secret_id_num_uses"`
+	CreationTime     *time.Time        `json:"creation_time"`
+	ExpirationTime   *time.Time        `json:"expiration_time"`
+	LastUpdatedTime  *time.Time        `json:"last_updated_time"`
+}
+
+type KubernetesAuthConfig struct {
+	KubernetesHost       string   `json:"kubernetes_host"`
+	KubernetesCaCert     string   `json:"kubernetes_ca_cert,omitempty"`
+	TokenReviewerJwt     string   `json:"token_reviewer_jwt,omitempty"`
+	PemKeys              []string `json:"pem_keys,omitempty"`
+	Issuer               string   `json:"issuer,omitempty"`
+	DisableIssValidation bool     `json:"disable_iss_validation,omitempty"`
+	DisableLocalCaJwt    bool     `json:"disable_local_ca_jwt,omitempty"`
+}
+
+type KubernetesRole struct {
+	AuthTokenConfig
+	BoundServiceAccountNames      []string `json:"bound_service_account_names"`
+	BoundServiceAccountNamespaces []string `json:"bound_service_account_namespaces"`
+	Audience                      string   `json:"audience,omitempty"`
+	AliasNameSource               string   `json:"alias_name_source,omitempty"` // `serviceaccount_uid` or `serviceaccount_name`
+}
+
+// JWTAuthConfig set one of OidcDiscoveryUrl, JwksUrl or JwtValidationPubkeys
+type JWTAuthConfig struct {
+	OidcDiscoveryUrl     string   `json:"oidc_discovery_url,omitempty"`
+	OidcDiscoveryCaPem   string   `json:"oidc_discovery_ca_pem,omitempty"`
+	OidcClientId         string   `json:"oidc_client_id,omitempty"`
+	OidcClientSecret     string   `json:"oidc_client_secret,omitempty"`
+	JwksUrl              string   `json:"jwks_url,omitempty"`
+	JwksCaPem            string   `json:"jwks_ca_pem,omitempty"`
+	JwtValidationPubkeys []string `json:"jwt_validation_pubkeys,omitempty"`
+	JwtSupportedAlgs     []string `json:"jwt_supported_algs,omitempty"`
+	BoundIssuer          string   `json:"bound_issuer,omitempty"`
+	DefaultRole          string   `json:"default_role,omitempty"`
+}
+
+type JWTRoleType string
+
+const (
+	JWTRoleJWT  JWTRoleType = "jwt"
+	JWTRoleOIDC JWTRoleType = "oidc"
+)
+
+type JWTRole struct {
+	AuthTokenConfig
+	RoleType            JWTRoleType            `json:"role_type,omitempty"`
+	UserClaim           string                 `json:"user_claim"`
+	BoundAudiences      []string               `json:"bound_audiences,omitempty"`
+	BoundSubject        string                 `json:"bound_subject,omitempty"`
+	BoundClaims         map[string]interface{} `json:"bound_claims,omitempty"`
+	BoundClaimsType     string                 `json:"bound_claims_type,omitempty"` // `string` or `glob`
+	GroupsClaim         string                 `json:"groups_claim,omitempty"`
+	ClaimMappings       map[string]string      `json:"claim_mappings,omitempty"`
+	AllowedRedirectUris []string               `json:"allowed_redirect_uris,omitempty"`
+	OidcScopes          []string               `json:"oidc_scopes,omitempty"`
+	ClockSkewLeeway     int                    `json:"clock_skew_leeway,omitempty"` // in seconds
+	ExpirationLeeway    int                    `json:"expiration_leeway,omitempty"` // in seconds
+	NotBeforeLeeway     int                    `json:"not_before_leeway,omitempty"` // in seconds
+}
+
+// TokenCreateOptions Role create token against the token role, Orphan create token without parent (require sudo).
+// Orphan is ignored when Role is set, use TokenRole.Orphan instead.
+type TokenCreateOptions struct {
+	Role            string            `json:"-"`
+	Orphan          bool              `json:"-"`
+	Id              string            `json:"id,omitempty"`
+	Policies        []string          `json:"policies,omitempty"`
+	Metadata        map[string]string `json:"meta,omitempty"`
+	NoDefaultPolicy bool              `json:"no_default_policy,omitempty"`
+	Renewable       *bool             `json:"renewable,omitempty"`
+	Ttl             int               `json:"ttl,omitempty"`              // in seconds
+	ExplicitMaxTtl  int               `json:"explicit_max_ttl,omitempty"` // in seconds
+	Period          int               `json:"period,omitempty"`           // in seconds, periodic token when not zero
+	NumUses         int               `json:"num_uses,omitempty"`
+	DisplayName     string            `json:"display_name,omitempty"`
+	EntityAlias     string            `json:"entity_alias,omitempty"`
+	Type            string            `json:"type,omitempty"` // `service` or `batch`
+}
+
+type TokenAuth struct {
+	ClientToken      string            `json:"client_token"`
+	Accessor         string            `json:"accessor"`
+	Policies         []string          `json:"policies"`
+	TokenPolicies    []string          `json:"token_policies"`
+	IdentityPolicies []string          `json:"identity_policies"`
+	Metadata         map[string]string `json:"metadata"`
+	Orphan           bool              `json:"orphan"`
+	EntityId         string            `json:"entity_id"`
+	LeaseDuration    int               `json:"lease_duration"`
+	Renewable        bool              `json:"renewable"`
+}
+
+// TokenInfo Id is empty when looked up by accessor
+type TokenInfo struct {
+	Id             string            `json:"id"`
+	Accessor       string            `json:"accessor"`
+	Type           string            `json:"type"`
+	DisplayName    string            `json:"display_name"`
+	Path           string            `json:"path"`
+	Policies       []string          `json:"policies"`
+	Metadata       map[string]string `json:"meta"`
+	EntityId       string            `json:"entity_id"`
+	Orphan         bool              `json:"orphan"`
+	Renewable      bool              `json:"renewable"`
+	NumUses        int               `json:"num_uses"`
+	CreationTime   int64             `json:"creation_time"` // unix time
+	CreationTtl    int               `json:"creation_ttl"`
+	ExplicitMaxTtl int               `json:"explicit_max_ttl"`
+	Period         int               `json:"period"`
+	Ttl            int               `json:"ttl"`
+	IssueTime      *time.Time        `json:"issue_time"`
+	ExpireTime     *time.Time        `json:"expire_time"`
+}
+
+type TokenRole struct {
+	AllowedPolicies      []string `json:"allowed_policies,omitempty"`
+	DisallowedPolicies   []string `json:"disallowed_policies,omitempty"`
+	AllowedEntityAliases []string `json:"allowed_entity_aliases,omitempty"`
+	Orphan               bool     `json:"orphan,omitempty"`
+	Renewable            *bool    `json:"renewable,omitempty"`
+	PathSuffix           string   `json:"path_suffix,omitempty"`
+	TokenBoundCidrs      []string `json:"token_bound_cidrs,omitempty"`
+	TokenExplicitMaxTtl  int      `json:"token_explicit_max_ttl,omitempty"` // in seconds
+	TokenPeriod          int      `json:"token_period,omitempty"`           // in seconds
+	TokenNoDefaultPolicy bool     `json:"token_no_default_policy,omitempty"`
+	TokenNumUses         int      `json:"token_num_uses,omitempty"`
+	TokenType            string   `json:"token_type,omitempty"`
+}
+
+type HealthStatus struct {
+	Initialized                bool   `json:"initialized"`
+	Sealed                     bool   `json:"sealed"`
+	Standby                    bool   `json:"standby"`
+	PerformanceStandby         bool   `json:"performance_standby"`
+	ReplicationPerformanceMode string `json:"replication_performance_mode"`
+	ReplicationDrMode          string `json:"replication_dr_mode"`
+	ServerTimeUtc              int64  `json:"server_time_utc"` // unix time
+	Version                    string `json:"version"`
+	ClusterName                string `json:"cluster_name"`
+	ClusterId                  string `json:"cluster_id"`
+}
+
+// SealStatus Threshold unseal keys of Shares are required, Progress is the number of keys provided so far
+type SealStatus struct {
+	Type         string `json:"type"`
+	Initialized  bool   `json:"initialized"`
+	Sealed       bool   `json:"sealed"`
+	Threshold    int    `json:"t"`
+	Shares       int    `json:"n"`
+	Progress     int    `json:"progress"`
+	Nonce        string `json:"nonce"`
+	Version      string `json:"version"`
+	Migration    bool   `json:"migration"`
+	RecoverySeal bool   `json:"recovery_seal"`
+	StorageType  string `json:"storage_type"`
+	ClusterName  string `json:"cluster_name"`
+	ClusterId    string `json:"cluster_id"`
+}
+
+type LeaderStatus struct {
+	HAEnabled            bool       `json:"ha_enabled"`
+	IsSelf               bool       `json:"is_self"`
+	ActiveTime           *time.Time `json:"active_time"`
+	LeaderAddress        string     `json:"leader_address"`
+	LeaderClusterAddress string     `json:"leader_cluster_address"`
+	PerformanceStandby   bool       `json:"performance_standby"`
+	RaftCommittedIndex   int64      `json:"raft_committed_index"`
+	RaftAppliedIndex     int64      `json:"raft_applied_index"`
+}
+
+type HAStatus struct {
+	Nodes []HANode `json:"nodes"`
+}
+
+type HANode struct {
+	Hostname       string     `json:"hostname"`
+	ApiAddress     string     `json:"api_address"`
+	ClusterAddress string     `json:"cluster_address"`
+	ActiveNode     bool       `json:"active_node"`
+	LastEcho       *time.Time `json:"last_echo"`
+}
+
+type VersionHistory struct {
+	Version            string     `json:"version"`
+	PreviousVersion    string     `json:"previous_version"`
+	BuildDate          string     `json:"build_date"`
+	TimestampInstalled *time.Time `json:"timestamp_installed"`
+}
+
+type AuditType string
+
+const (
+	FileAudit   AuditType = "file"
+	SyslogAudit AuditType = "syslog"
+	SocketAudit AuditType = "socket"
+)
+
+// AuditDevice Path is filled by Vault and ignored on write, use NewFileAudit, NewSyslogAudit
+// or NewSocketAudit to build Options from typed options
+type AuditDevice struct {
+	Type        AuditType         `json:"type"`
+	Description string            `json:"description,omitempty"`
+	Local       bool              `json:"local,omitempty"`
+	Options     map[string]string `json:"options,omitempty"`
+	Path        string            `json:"path,omitempty"`
+}
+
+// AuditOptions are shared by all audit device types
+type AuditOptions struct {
+	Format             string `json:"format,omitempty"` // `json` or `jsonx`
+	Prefix             string `json:"prefix,omitempty"`
+	HmacAccessor       *bool  `json:"hmac_accessor,omitempty"`
+	LogRaw             bool   `json:"log_raw,omitempty"`
+	ElideListResponses bool   `json:"elide_list_responses,omitempty"`
+}
+
+type FileAuditOptions struct {
+	AuditOptions
+	FilePath string `json:"file_path"`      // `stdout` and `discard` are special values
+	Mode     string `json:"mode,omitempty"` // octal file mode, e.g. `0600`
+}
+
+type SyslogAuditOptions struct {
+	AuditOptions
+	Facility string `json:"facility,omitempty"`
+	Tag      string `json:"tag,omitempty"`
+}
+
+type SocketAuditOptions struct {
+	AuditOptions
+	Address      string `json:"address"`
+	SocketType   string `json:"socket_type,omitempty"`   // `tcp`, `udp` or `unix`
+	WriteTimeout string `json:"write_timeout,omitempty"` //use go duration format https://golang.org/pkg/time/#ParseDuration
+}
+
+// InitOptions PgpKeys and RecoveryPgpKeys are base64 encoded public keys or `keybase:<user>`, one per share
+type InitOptions struct {
+	SecretShares      int      `json:"secret_shares"`
+	SecretThreshold   int      `json:"secret_threshold"`
+	PgpKeys           []string `json:"pgp_keys,omitempty"`
+	RootTokenPgpKey   string   `json:"root_token_pgp_key,omitempty"`
+	StoredShares      int      `json:"stored_shares,omitempty"`
+	RecoveryShares    int      `json:"recovery_shares,omitempty"`
+	RecoveryThreshold int      `json:"recovery_threshold,omitempty"`
+	RecoveryPgpKeys   []string `json:"recovery_pgp_keys,omitempty"`
+}
+
+// InitResult keys are PGP encrypted when InitOptions.PgpKeys is set, decrypt them with DecryptPGPShare
+type InitResult struct {
+	Keys               []string `json:"keys"` // hex encoded
+	KeysBase64         []string `json:"keys_base64"`
+	RecoveryKeys       []string `json:"recovery_keys"`
+	RecoveryKeysBase64 []string `json:"recovery_keys_base64"`
+	RootToken          string   `json:"root_token"`
+}
+
+type RekeyOptions struct {
+	SecretShares        int      `json:"secret_shares"`
+	SecretThreshold     int      `json:"secret_threshold"`
+	PgpKeys             []string `json:"pgp_keys,omitempty"`
+	Backup              bool     `json:"backup,omitempty"`
+	RequireVerification bool     `json:"require_verification,omitempty"`
+}
+
+// RekeyStatus Keys and KeysBase64 are only set once all shares of the current keys are submitted
+type RekeyStatus struct {
+	Nonce                string   `json:"nonce"`
+	Started              bool     `json:"started"`
+	Threshold            int      `json:"t"`
+	Shares               int      `json:"n"`
+	Progress             int      `json:"progress"`
+	Required             int      `json:"required"`
+	PgpFingerprints      []string `json:"pgp_fingerprints"`
+	Backup               bool     `json:"backup"`
+	VerificationRequired bool     `json:"verification_required"`
+	VerificationNonce    string   `json:"verification_nonce"`
+	Complete             bool     `json:"complete"`
+	Keys                 []string `json:"keys"`
+	KeysBase64           []string `json:"keys_base64"`
+}
+
+type GenerateRootStatus struct {
+	Nonce            string `json:"nonce"`
+	Started          bool   `json:"started"`
+	Progress         int    `json:"progress"`
+	Required         int    `json:"required"`
+	Complete         bool   `json:"complete"`
+	EncodedToken     string `json:"encoded_token"`
+	EncodedRootToken string `json:"encoded_root_token"` // deprecated name of EncodedToken, set by older Vault
+	PgpFingerprint   string `json:"pgp_fingerprint"`
+	OtpLength        int    `json:"otp_length"`
+}
+
+type RaftPeer struct {
+	NodeId          string `json:"node_id"`
+	Address         string `json:"address"`
+	Leader          bool   `json:"leader"`
+	ProtocolVersion string `json:"protocol_version"`
+	Voter           bool   `json:"voter"`
+}
+
+// AutopilotConfig CleanupDeadServers is always sent, the other zero fields keep the current value
+type AutopilotConfig struct {
+	CleanupDeadServers             bool   `json:"cleanup_dead_servers"`
+	LastContactThreshold           string `json:"last_contact_threshold,omitempty"`             //use go duration format https://golang.org/pkg/time/#ParseDuration
+	DeadServerLastContactThreshold string `json:"dead_server_last_contact_threshold,omitempty"` //use go duration format https://golang.org/pkg/time/#ParseDuration
+	MaxTrailingLogs                int    `json:"max_trailing_logs,omitempty"`
+	MinQuorum                      int    `json:"min_quorum,omitempty"`
+	ServerStabilizationTime        string `json:"server_stabilization_time,omitempty"` //use go duration format https://golang.org/pkg/time/#ParseDuration
+}
+
+type AutopilotState struct {
+	Healthy          bool                       `json:"healthy"`
+	FailureTolerance int                        `json:"failure_tolerance"`
+	Leader           string                     `json:"leader"`
+	Voters           []string                   `json:"voters"`
+	NonVoters        []string                   `json:"non_voters"`
+	Servers          map[string]AutopilotServer `json:"servers"`
+}
+
+type AutopilotServer struct {
+	Id          string            `json:"id"`
+	Name        string            `json:"name"`
+	Address     string            `json:"address"`
+	NodeStatus  string            `json:"node_status"`
+	Status      string            `json:"status"` // `leader`, `voter` or `non-voter`
+	Healthy     bool              `json:"healthy"`
+	LastContact string            `json:"last_contact"` // go duration format
+	LastTerm    int64             `json:"last_term"`
+	LastIndex   int64             `json:"last_index"`
+	StableSince *time.Time        `json:"stable_since"`
+	Meta        map[string]string `json:"meta"`
 }
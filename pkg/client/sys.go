@@ -0,0 +1,138 @@
+package client
+
+import (
+	"context"
+	"github.com/hashicorp/vault/api"
+	"github.com/jasoet/vault-client/pkg/util"
+)
+
+type systemEngine struct {
+	vaultClient *api.Client
+}
+
+// Health report status of the node without error on standby, sealed or uninitialized node
+func (s systemEngine) Health() (status *HealthStatus, err error) {
+	return s.health(context.Background())
+}
+
+func (s systemEngine) health(ctx context.Context) (status *HealthStatus, err error) {
+	request := s.vaultClient.NewRequest("GET", "/v1/sys/health")
+	// sys/health report node state with status code, map all of them to 200 so the body is decoded
+	for _, param := range []string{"standbycode", "sealedcode", "uninitcode", "drsecondarycode", "performancestandbycode"} {
+		request.Params.Set(param, "200")
+	}
+
+	status = new(HealthStatus)
+	err = rawRequestWithContext(ctx, s.vaultClient, request, status)
+	if err != nil {
+		status = nil
+	}
+	return
+}
+
+func (s systemEngine) SealStatus() (status *SealStatus, err error) {
+	status = new(SealStatus)
+	err = rawRequest(s.vaultClient, s.vaultClient.NewRequest("GET", "/v1/sys/seal-status"), status)
+	if err != nil {
+		status = nil
+	}
+	return
+}
+
+// Leader decode through util.MapToStruct because Vault return "" active_time when there is no active node
+func (s systemEngine) Leader() (status *LeaderStatus, err error) {
+	result := map[string]interface{}{}
+	err = rawRequest(s.vaultClient, s.vaultClient.NewRequest("GET", "/v1/sys/leader"), &result)
+	if err != nil {
+		return
+	}
+
+	status = new(LeaderStatus)
+	err = util.MapToStruct(result, status)
+	if err != nil {
+		status = nil
+	}
+	return
+}
+
+// HAStatus decode through util.MapToStruct because Vault return "" last_echo for the active node
+func (s systemEngine) HAStatus() (status *HAStatus, err error) {
+	result := map[string]interface{}{}
+	err = rawRequest(s.vaultClient, s.vaultClient.NewRequest("GET", "/v1/sys/ha-status"), &result)
+	if err != nil {
+		return
+	}
+
+	status = new(HAStatus)
+	err = util.MapToStruct(result, status)
+	if err != nil {
+		status = nil
+	}
+	return
+}
+
+// VersionHistory return installed versions ordered by install time, oldest first
+func (s systemEngine) VersionHistory() (history []VersionHistory, err error) {
+	result, err := s.vaultClient.Logical().List("sys/version-history")
+	if err != nil {
+		return
+	}
+
+	history = []VersionHistory{}
+	if result == nil {
+		return
+	}
+
+	keys, ok := result.Data["keys"].([]interface{})
+	if !ok {
+		return
+	}
+	keyInfo, _ := result.Data["key_info"].(map[string]interface{})
+
+	for _, version := range util.ToArrStr(keys) {
+		entry := VersionHistory{}
+		if info, ok := keyInfo[version].(map[string]interface{}); ok {
+			err = util.MapToStruct(info, &entry)
+			if err != nil {
+				return nil, err
+			}
+		}
+		entry.Version = version
+		history = append(history, entry)
+	}
+	return
+}
+
+// rawRequest send request and decode the response body into output, used for sys endpoints not wrapped in a secret.
+// nil output ignore the response body
+func rawRequest(vaultClient *api.Client, request *api.Request, output interface{}) (err error) {
+	return rawRequestWithContext(context.Background(), vaultClient, request, output)
+}
+
+// rawRequestWithContext is rawRequest cancelled when ctx is done
+func rawRequestWithContext(ctx context.Context, vaultClient *api.Client, request *api.Request, output interface{}) (err error) {
+	response, err := vaultClient.RawRequestWithContext(ctx, request)
+	if response != nil {
+		defer response.Body.Close()
+	}
+	if err != nil || output == nil {
+		return
+	}
+
+	return response.DecodeJSON(output)
+}
+
+func DefaultSystem() (system System, err error) {
+	vaultClient, err := api.NewClient(api.DefaultConfig())
+	if err != nil {
+		return
+	}
+
+	system = &systemEngine{vaultClient: vaultClient}
+	return
+}
+
+func NewSystem(vaultClient *api.Client) (system System, err error) {
+	system = &systemEngine{vaultClient: vaultClient}
+	return
+}
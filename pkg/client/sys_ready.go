@@ -0,0 +1,44 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// WaitUntilReady poll Health every interval until the node is initialized, unsealed and active,
+// unreachable Vault is retried until ctx is done. ctx also cancel the pending health request,
+// the returned error keep the last reason seen before ctx is done
+func (s systemEngine) WaitUntilReady(ctx context.Context, interval time.Duration) error {
+	if interval <= 0 {
+		return fmt.Errorf("interval must be positive: %v", interval)
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	var reason error
+	for {
+		status, err := s.health(ctx)
+		switch {
+		case err != nil && ctx.Err() != nil && reason != nil:
+			err = reason
+		case err != nil:
+		case !status.Initialized:
+			err = fmt.Errorf("vault is not initialized")
+		case status.Sealed:
+			err = fmt.Errorf("vault is sealed")
+		case status.Standby:
+			err = fmt.Errorf("vault is standby")
+		default:
+			return nil
+		}
+
+		reason = err
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("vault is not ready: %v", err)
+		case <-ticker.C:
+		}
+	}
+}
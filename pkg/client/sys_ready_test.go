@@ -0,0 +1,103 @@
+package client_test
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/hashicorp/vault/api"
+	. "github.com/jasoet/vault-client/pkg/client"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// healthServer serve sys/health from states in order, the last state is repeated
+func healthServer(t *testing.T, states ...HealthStatus) (*httptest.Server, *int32) {
+	calls := new(int32)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/v1/sys/health", r.URL.Path)
+		assert.Equal(t, "200", r.URL.Query().Get("sealedcode"))
+
+		index := int(atomic.AddInt32(calls, 1)) - 1
+		if index >= len(states) {
+			index = len(states) - 1
+		}
+		_ = json.NewEncoder(w).Encode(states[index])
+	}))
+	return server, calls
+}
+
+func newSystem(t *testing.T, address string) System {
+	vaultClient, err := api.NewClient(&api.Config{Address: address})
+	require.NoError(t, err)
+
+	system, err := NewSystem(vaultClient)
+	require.NoError(t, err)
+	return system
+}
+
+func TestSystemWaitUntilReady(t *testing.T) {
+	t.Run("should wait until initialized, unsealed and active", func(t *testing.T) {
+		server, calls := healthServer(t,
+			HealthStatus{Initialized: false, Sealed: true},
+			HealthStatus{Initialized: true, Sealed: true},
+			HealthStatus{Initialized: true, Sealed: false, Standby: true},
+			HealthStatus{Initialized: true, Sealed: false},
+		)
+		defer server.Close()
+
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+
+		err := newSystem(t, server.URL).WaitUntilReady(ctx, 10*time.Millisecond)
+		assert.NoError(t, err)
+		assert.Equal(t, int32(4), atomic.LoadInt32(calls))
+	})
+
+	t.Run("should return last reason when context is done", func(t *testing.T) {
+		server, _ := healthServer(t, HealthStatus{Initialized: true, Sealed: true})
+		defer server.Close()
+
+		ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+		defer cancel()
+
+		err := newSystem(t, server.URL).WaitUntilReady(ctx, 10*time.Millisecond)
+		assert.EqualError(t, err, "vault is not ready: vault is sealed")
+	})
+
+	t.Run("should retry unreachable vault", func(t *testing.T) {
+		server, _ := healthServer(t, HealthStatus{Initialized: true})
+		address := server.URL
+		server.Close()
+
+		ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+		defer cancel()
+
+		err := newSystem(t, address).WaitUntilReady(ctx, 10*time.Millisecond)
+		assert.Error(t, err)
+	})
+	t.Run("should cancel pending health request when context is done", func(t *testing.T) {
+		release := make(chan struct{})
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			<-release
+		}))
+		defer server.Close()
+		defer close(release)
+
+		ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+		defer cancel()
+
+		started := time.Now()
+		err := newSystem(t, server.URL).WaitUntilReady(ctx, time.Second)
+		assert.Error(t, err)
+		assert.Less(t, int64(time.Since(started)), int64(time.Second))
+	})
+
+	t.Run("should reject non positive interval", func(t *testing.T) {
+		err := newSystem(t, "http://127.0.0.1:8200").WaitUntilReady(context.Background(), 0)
+		assert.EqualError(t, err, "interval must be positive: 0s")
+	})
+}
@@ -0,0 +1,60 @@
+package client_test
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+// statusServer serve body as response of path
+func statusServer(t *testing.T, path string, body string) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, path, r.URL.Path)
+		_, _ = w.Write([]byte(body))
+	}))
+}
+
+func TestSystemLeader(t *testing.T) {
+	t.Run("should decode active time", func(t *testing.T) {
+		server := statusServer(t, "/v1/sys/leader", `{"ha_enabled":true,"is_self":true,"active_time":"2021-06-30T01:02:03.5Z","leader_address":"https://vault:8200","raft_committed_index":42}`)
+		defer server.Close()
+
+		status, err := newSystem(t, server.URL).Leader()
+		require.NoError(t, err)
+		require.NotNil(t, status.ActiveTime)
+		assert.Equal(t, time.Date(2021, 6, 30, 1, 2, 3, 500000000, time.UTC), *status.ActiveTime)
+		assert.Equal(t, "https://vault:8200", status.LeaderAddress)
+		assert.Equal(t, int64(42), status.RaftCommittedIndex)
+	})
+
+	t.Run("should decode empty active time as nil", func(t *testing.T) {
+		server := statusServer(t, "/v1/sys/leader", `{"ha_enabled":true,"is_self":false,"active_time":"","leader_address":""}`)
+		defer server.Close()
+
+		status, err := newSystem(t, server.URL).Leader()
+		require.NoError(t, err)
+		assert.True(t, status.HAEnabled)
+		assert.Nil(t, status.ActiveTime)
+	})
+}
+
+func TestSystemHAStatus(t *testing.T) {
+	t.Run("should decode empty last echo as nil", func(t *testing.T) {
+		server := statusServer(t, "/v1/sys/ha-status", `{"nodes":[
+			{"hostname":"vault-0","api_address":"https://vault-0:8200","active_node":true,"last_echo":""},
+			{"hostname":"vault-1","api_address":"https://vault-1:8200","active_node":false,"last_echo":"2021-06-30T01:02:03Z"}
+		]}`)
+		defer server.Close()
+
+		status, err := newSystem(t, server.URL).HAStatus()
+		require.NoError(t, err)
+		require.Len(t, status.Nodes, 2)
+		assert.True(t, status.Nodes[0].ActiveNode)
+		assert.Nil(t, status.Nodes[0].LastEcho)
+		require.NotNil(t, status.Nodes[1].LastEcho)
+		assert.Equal(t, time.Date(2021, 6, 30, 1, 2, 3, 0, time.UTC), *status.Nodes[1].LastEcho)
+	})
+}
@@ -0,0 +1,73 @@
+// +build integration
+
+package client_test
+
+import (
+	"github.com/hashicorp/vault/api"
+	. "github.com/jasoet/vault-client/pkg/client"
+	"github.com/stretchr/testify/assert"
+	"os"
+	"testing"
+)
+
+type systemTestCtx struct {
+	vaultClient *api.Client
+}
+
+func (ctx *systemTestCtx) setup(t *testing.T) {
+	config := &api.Config{Address: os.Getenv("TEST_VAULT_ADDR")}
+	client, err := api.NewClient(config)
+	assert.Nil(t, err)
+
+	client.SetToken(os.Getenv("TEST_VAULT_TOKEN"))
+
+	ctx.vaultClient = client
+}
+
+func TestSystem(t *testing.T) {
+	ctx := new(systemTestCtx)
+	ctx.setup(t)
+
+	engine, err := NewSystem(ctx.vaultClient)
+	assert.Nil(t, err)
+	assert.NotNil(t, engine)
+
+	t.Run("health should report active node", func(t *testing.T) {
+		health, err := engine.Health()
+		assert.Nil(t, err)
+		assert.True(t, health.Initialized)
+		assert.False(t, health.Sealed)
+		assert.False(t, health.Standby)
+		assert.NotEmpty(t, health.Version)
+		assert.NotZero(t, health.ServerTimeUtc)
+	})
+
+	t.Run("seal status should report unsealed", func(t *testing.T) {
+		status, err := engine.SealStatus()
+		assert.Nil(t, err)
+		assert.True(t, status.Initialized)
+		assert.False(t, status.Sealed)
+		assert.NotEmpty(t, status.Type)
+		assert.True(t, status.Threshold > 0)
+	})
+
+	t.Run("leader should return result", func(t *testing.T) {
+		leader, err := engine.Leader()
+		assert.Nil(t, err)
+		assert.NotNil(t, leader)
+		if leader.HAEnabled {
+			assert.True(t, leader.IsSelf)
+		}
+	})
+
+	t.Run("version history should contain running version", func(t *testing.T) {
+		health, err := engine.Health()
+		assert.Nil(t, err)
+
+		history, err := engine.VersionHistory()
+		assert.Nil(t, err)
+		if len(history) > 0 {
+			assert.Equal(t, health.Version, history[len(history)-1].Version)
+		}
+	})
+}
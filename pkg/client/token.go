@@ -0,0 +1,225 @@
+package client
+
+import (
+	"fmt"
+	"github.com/hashicorp/vault/api"
+	"github.com/jasoet/vault-client/pkg/util"
+)
+
+type tokenEngine struct {
+	vaultClient *api.Client
+}
+
+func (t tokenEngine) Create(options TokenCreateOptions) (auth *TokenAuth, err error) {
+	path := "auth/token/create"
+	if options.Role != "" {
+		path = fmt.Sprintf("auth/token/create/%v", options.Role)
+	} else if options.Orphan {
+		path = "auth/token/create-orphan"
+	}
+
+	payload, err := util.StructToMapE(options)
+	if err != nil {
+		return
+	}
+
+	result, err := t.vaultClient.Logical().Write(path, payload)
+	if err != nil {
+		return
+	}
+
+	return toTokenAuth(result)
+}
+
+func (t tokenEngine) Lookup(token string) (info *TokenInfo, err error) {
+	payload := map[string]interface{}{
+		"token": token,
+	}
+	return t.lookup("auth/token/lookup", payload)
+}
+
+func (t tokenEngine) LookupSelf() (info *TokenInfo, err error) {
+	result, err := t.vaultClient.Logical().Read("auth/token/lookup-self")
+	if err != nil {
+		return
+	}
+
+	return toTokenInfo(result)
+}
+
+func (t tokenEngine) LookupAccessor(accessor string) (info *TokenInfo, err error) {
+	payload := map[string]interface{}{
+		"accessor": accessor,
+	}
+	return t.lookup("auth/token/lookup-accessor", payload)
+}
+
+// Renew increment in seconds, zero increment use token ttl
+func (t tokenEngine) Renew(token string, increment int) (auth *TokenAuth, err error) {
+	payload := map[string]interface{}{
+		"token": token,
+	}
+	if increment > 0 {
+		payload["increment"] = increment
+	}
+
+	result, err := t.vaultClient.Logical().Write("auth/token/renew", payload)
+	if err != nil {
+		return
+	}
+
+	return toTokenAuth(result)
+}
+
+// RenewSelf increment in seconds, zero increment use token ttl
+func (t tokenEngine) RenewSelf(increment int) (auth *TokenAuth, err error) {
+	payload := map[string]interface{}{}
+	if increment > 0 {
+		payload["increment"] = increment
+	}
+
+	result, err := t.vaultClient.Logical().Write("auth/token/renew-self", payload)
+	if err != nil {
+		return
+	}
+
+	return toTokenAuth(result)
+}
+
+// Revoke revoke the token and all of its children
+func (t tokenEngine) Revoke(token string) (err error) {
+	payload := map[string]interface{}{
+		"token": token,
+	}
+	_, err = t.vaultClient.Logical().Write("auth/token/revoke", payload)
+	return
+}
+
+func (t tokenEngine) RevokeAccessor(accessor string) (err error) {
+	payload := map[string]interface{}{
+		"accessor": accessor,
+	}
+	_, err = t.vaultClient.Logical().Write("auth/token/revoke-accessor", payload)
+	return
+}
+
+// RevokeOrphan revoke the token only, its children become orphan. Require sudo
+func (t tokenEngine) RevokeOrphan(token string) (err error) {
+	payload := map[string]interface{}{
+		"token": token,
+	}
+	_, err = t.vaultClient.Logical().Write("auth/token/revoke-orphan", payload)
+	return
+}
+
+func (t tokenEngine) CreateRole(name string, role TokenRole) (err error) {
+	payload, err := util.StructToMapE(role)
+	if err != nil {
+		return
+	}
+
+	_, err = t.vaultClient.Logical().Write(fmt.Sprintf("auth/token/roles/%v", name), payload)
+	return
+}
+
+func (t tokenEngine) ReadRole(name string) (role *TokenRole, err error) {
+	result, err := t.vaultClient.Logical().Read(fmt.Sprintf("auth/token/roles/%v", name))
+	if err != nil {
+		return
+	}
+
+	if result == nil {
+		err = fmt.Errorf("%v is not found", name)
+		return
+	}
+
+	role = new(TokenRole)
+	err = util.MapToStruct(result.Data, role)
+	return
+}
+
+func (t tokenEngine) DeleteRole(name string) (err error) {
+	_, err = t.vaultClient.Logical().Delete(fmt.Sprintf("auth/token/roles/%v", name))
+	return
+}
+
+func (t tokenEngine) ListRole() (list []string, err error) {
+	result, err := t.vaultClient.Logical().List("auth/token/roles")
+	if err != nil || result == nil {
+		return []string{}, nil
+	}
+
+	if val, ok := result.Data["keys"]; ok {
+		list = util.ToArrStr(val.([]interface{}))
+	}
+	return
+}
+
+// ListAccessor list accessors of all tokens, require sudo
+func (t tokenEngine) ListAccessor() (list []string, err error) {
+	result, err := t.vaultClient.Logical().List("auth/token/accessors")
+	if err != nil || result == nil {
+		return []string{}, nil
+	}
+
+	if val, ok := result.Data["keys"]; ok {
+		list = util.ToArrStr(val.([]interface{}))
+	}
+	return
+}
+
+func (t tokenEngine) lookup(path string, payload map[string]interface{}) (info *TokenInfo, err error) {
+	result, err := t.vaultClient.Logical().Write(path, payload)
+	if err != nil {
+		return
+	}
+
+	return toTokenInfo(result)
+}
+
+func toTokenInfo(secret *api.Secret) (info *TokenInfo, err error) {
+	if secret == nil {
+		err = fmt.Errorf("token is not found")
+		return
+	}
+
+	info = new(TokenInfo)
+	err = util.MapToStruct(secret.Data, info)
+	return
+}
+
+func toTokenAuth(secret *api.Secret) (auth *TokenAuth, err error) {
+	if secret == nil || secret.Auth == nil {
+		err = fmt.Errorf("token auth is empty")
+		return
+	}
+
+	auth = &TokenAuth{
+		ClientToken:      secret.Auth.ClientToken,
+		Accessor:         secret.Auth.Accessor,
+		Policies:         secret.Auth.Policies,
+		TokenPolicies:    secret.Auth.TokenPolicies,
+		IdentityPolicies: secret.Auth.IdentityPolicies,
+		Metadata:         secret.Auth.Metadata,
+		Orphan:           secret.Auth.Orphan,
+		EntityId:         secret.Auth.EntityID,
+		LeaseDuration:    secret.Auth.LeaseDuration,
+		Renewable:        secret.Auth.Renewable,
+	}
+	return
+}
+
+func DefaultToken() (token Token, err error) {
+	vaultClient, err := api.NewClient(api.DefaultConfig())
+	if err != nil {
+		return
+	}
+
+	token = &tokenEngine{vaultClient: vaultClient}
+	return
+}
+
+func NewToken(vaultClient *api.Client) (token Token, err error) {
+	token = &tokenEngine{vaultClient: vaultClient}
+	return
+}
@@ -0,0 +1,162 @@
+// +build integration
+
+package client_test
+
+import (
+	"github.com/hashicorp/vault/api"
+	. "github.com/jasoet/vault-client/pkg/client"
+	"github.com/stretchr/testify/assert"
+	"os"
+	"testing"
+)
+
+type tokenTestCtx struct {
+	vaultClient *api.Client
+}
+
+func (ctx *tokenTestCtx) setup(t *testing.T) {
+	config := &api.Config{Address: os.Getenv("TEST_VAULT_ADDR")}
+	client, err := api.NewClient(config)
+	assert.Nil(t, err)
+
+	client.SetToken(os.Getenv("TEST_VAULT_TOKEN"))
+
+	ctx.vaultClient = client
+}
+
+func TestToken(t *testing.T) {
+	ctx := new(tokenTestCtx)
+	ctx.setup(t)
+
+	engine, err := NewToken(ctx.vaultClient)
+	assert.Nil(t, err)
+	assert.NotNil(t, engine)
+
+	var auth *TokenAuth
+	t.Run("should create token with policies, metadata and num uses", func(t *testing.T) {
+		auth, err = engine.Create(TokenCreateOptions{
+			Policies:    []string{"default", "app-a"},
+			Metadata:    map[string]string{"app": "app-a"},
+			Ttl:         3600,
+			NumUses:     10,
+			DisplayName: "app-a",
+		})
+		assert.Nil(t, err)
+		assert.NotEmpty(t, auth.ClientToken)
+		assert.NotEmpty(t, auth.Accessor)
+		assert.Equal(t, 3600, auth.LeaseDuration)
+		assert.False(t, auth.Orphan)
+	})
+
+	t.Run("should lookup token by token and accessor", func(t *testing.T) {
+		info, err := engine.Lookup(auth.ClientToken)
+		assert.Nil(t, err)
+		assert.Equal(t, auth.Accessor, info.Accessor)
+		assert.Equal(t, map[string]string{"app": "app-a"}, info.Metadata)
+		assert.Equal(t, 10, info.NumUses)
+		assert.Equal(t, "token-app-a", info.DisplayName)
+		assert.NotNil(t, info.ExpireTime)
+
+		byAccessor, err := engine.LookupAccessor(auth.Accessor)
+		assert.Nil(t, err)
+		assert.Empty(t, byAccessor.Id)
+		assert.Equal(t, info.Policies, byAccessor.Policies)
+
+		accessors, err := engine.ListAccessor()
+		assert.Nil(t, err)
+		assert.Contains(t, accessors, auth.Accessor)
+	})
+
+	t.Run("should lookup and renew self", func(t *testing.T) {
+		client, err := ctx.vaultClient.Clone()
+		assert.Nil(t, err)
+		client.SetToken(auth.ClientToken)
+
+		self, err := NewToken(client)
+		assert.Nil(t, err)
+
+		info, err := self.LookupSelf()
+		assert.Nil(t, err)
+		assert.Equal(t, auth.Accessor, info.Accessor)
+
+		renewed, err := self.RenewSelf(1800)
+		assert.Nil(t, err)
+		assert.Equal(t, 1800, renewed.LeaseDuration)
+	})
+
+	t.Run("should create orphan and periodic token", func(t *testing.T) {
+		orphan, err := engine.Create(TokenCreateOptions{Orphan: true, Period: 600, Policies: []string{"default"}})
+		assert.Nil(t, err)
+		assert.True(t, orphan.Orphan)
+
+		info, err := engine.Lookup(orphan.ClientToken)
+		assert.Nil(t, err)
+		assert.Equal(t, 600, info.Period)
+
+		renewed, err := engine.Renew(orphan.ClientToken, 0)
+		assert.Nil(t, err)
+		assert.Equal(t, 600, renewed.LeaseDuration)
+
+		err = engine.RevokeAccessor(orphan.Accessor)
+		assert.Nil(t, err)
+		_, err = engine.Lookup(orphan.ClientToken)
+		assert.NotNil(t, err)
+	})
+
+	t.Run("revoke orphan should keep children", func(t *testing.T) {
+		client, err := ctx.vaultClient.Clone()
+		assert.Nil(t, err)
+		client.SetToken(auth.ClientToken)
+		parent, err := NewToken(client)
+		assert.Nil(t, err)
+
+		child, err := parent.Create(TokenCreateOptions{Policies: []string{"default"}})
+		assert.Nil(t, err)
+
+		err = engine.RevokeOrphan(auth.ClientToken)
+		assert.Nil(t, err)
+
+		info, err := engine.Lookup(child.ClientToken)
+		assert.Nil(t, err)
+		assert.True(t, info.Orphan)
+
+		err = engine.Revoke(child.ClientToken)
+		assert.Nil(t, err)
+	})
+
+	roleName := "app-role"
+	t.Run("should create token from role", func(t *testing.T) {
+		err := engine.CreateRole(roleName, TokenRole{
+			AllowedPolicies: []string{"app-a"},
+			Orphan:          true,
+			TokenPeriod:     3600,
+		})
+		assert.Nil(t, err)
+
+		role, err := engine.ReadRole(roleName)
+		assert.Nil(t, err)
+		assert.Equal(t, []string{"app-a"}, role.AllowedPolicies)
+		assert.True(t, role.Orphan)
+
+		list, err := engine.ListRole()
+		assert.Nil(t, err)
+		assert.Contains(t, list, roleName)
+
+		created, err := engine.Create(TokenCreateOptions{Role: roleName, Policies: []string{"app-a"}})
+		assert.Nil(t, err)
+		assert.True(t, created.Orphan)
+		assert.Contains(t, created.Policies, "app-a")
+
+		err = engine.Revoke(created.ClientToken)
+		assert.Nil(t, err)
+	})
+
+	t.Run("cannot fetch deleted role", func(t *testing.T) {
+		err := engine.DeleteRole(roleName)
+		assert.Nil(t, err)
+
+		role, err := engine.ReadRole(roleName)
+		assert.NotNil(t, err)
+		assert.Nil(t, role)
+	})
+}
@@ -0,0 +1,160 @@
+package client
+
+import (
+	"encoding/base64"
+	"fmt"
+	"github.com/hashicorp/vault/api"
+	"github.com/jasoet/vault-client/pkg/util"
+)
+
+type totpEngine struct {
+	vaultClient *api.Client
+	path        string
+}
+
+func (t totpEngine) Path() string {
+	return t.path
+}
+
+func (t totpEngine) Enable() (err error) {
+	data := map[string]interface{}{"type": "totp"}
+	_, err = t.vaultClient.Logical().Write(fmt.Sprintf("/sys/mounts/%v", t.path), data)
+	return
+}
+
+func (t totpEngine) Status() (status *SecretStatus, err error) {
+	result, err := t.vaultClient.Logical().Read(fmt.Sprintf("/sys/mounts/%v/tune", t.path))
+	if err != nil || result == nil {
+		return
+	}
+
+	status = new(SecretStatus)
+	err = util.MapToStruct(result.Data, status)
+
+	return
+}
+
+// CreateKey generate new key in Vault, barcode and url are only returned when key is exported (default)
+func (t totpEngine) CreateKey(name string, config TOTPKeyConfig) (key *TOTPKey, err error) {
+	payload, err := util.StructToMapE(config)
+	if err != nil {
+		return
+	}
+	payload["generate"] = true
+
+	result, err := t.vaultClient.Logical().Write(fmt.Sprintf("%v/keys/%v", t.path, name), payload)
+	if err != nil {
+		return
+	}
+
+	key = new(TOTPKey)
+	if result == nil {
+		return
+	}
+
+	err = util.MapToStruct(result.Data, key)
+	return
+}
+
+// ImportKey import existing key from `otpauth://totp/...` url, e.g. the one shown by another provider
+func (t totpEngine) ImportKey(name string, otpauthUrl string) (err error) {
+	payload := map[string]interface{}{
+		"url": otpauthUrl,
+	}
+	_, err = t.vaultClient.Logical().Write(fmt.Sprintf("%v/keys/%v", t.path, name), payload)
+	return
+}
+
+func (t totpEngine) ReadKey(name string) (config *TOTPKeyConfig, err error) {
+	result, err := t.vaultClient.Logical().Read(fmt.Sprintf("%v/keys/%v", t.path, name))
+	if err != nil {
+		return
+	}
+
+	if result == nil {
+		err = fmt.Errorf("%v is not found", name)
+		return
+	}
+
+	config = new(TOTPKeyConfig)
+	err = util.MapToStruct(result.Data, config)
+	return
+}
+
+func (t totpEngine) DeleteKey(name string) (err error) {
+	_, err = t.vaultClient.Logical().Delete(fmt.Sprintf("%v/keys/%v", t.path, name))
+	return
+}
+
+func (t totpEngine) ListKey() (list []string, err error) {
+	result, err := t.vaultClient.Logical().List(fmt.Sprintf("%v/keys", t.path))
+	if err != nil || result == nil {
+		return []string{}, nil
+	}
+
+	if val, ok := result.Data["keys"]; ok {
+		list = util.ToArrStr(val.([]interface{}))
+	}
+	return
+}
+
+func (t totpEngine) GenerateCode(name string) (code string, err error) {
+	result, err := t.vaultClient.Logical().Read(fmt.Sprintf("%v/code/%v", t.path, name))
+	if err != nil {
+		return
+	}
+
+	if result == nil {
+		err = fmt.Errorf("%v is not found", name)
+		return
+	}
+
+	code = fmt.Sprint(result.Data["code"])
+	return
+}
+
+func (t totpEngine) ValidateCode(name string, code string) (valid bool, err error) {
+	payload := map[string]interface{}{
+		"code": code,
+	}
+	result, err := t.vaultClient.Logical().Write(fmt.Sprintf("%v/code/%v", t.path, name), payload)
+	if err != nil {
+		return
+	}
+
+	if result == nil {
+		err = fmt.Errorf("%v is not found", name)
+		return
+	}
+
+	valid, _ = result.Data["valid"].(bool)
+	return
+}
+
+// BarcodePNG decode base64 barcode into PNG image of the key QR code
+func (k TOTPKey) BarcodePNG() ([]byte, error) {
+	if k.Barcode == "" {
+		return nil, fmt.Errorf("key barcode is empty, key is not exported")
+	}
+	return base64.StdEncoding.DecodeString(k.Barcode)
+}
+
+func DefaultTOTP() (TOTP, error) {
+	vaultClient, err := api.NewClient(api.DefaultConfig())
+	if err != nil {
+		return nil, err
+	}
+	return &totpEngine{vaultClient: vaultClient, path: "totp"}, nil
+}
+
+func NewTOTP(vaultClient *api.Client, path string) (TOTP, error) {
+	return &totpEngine{vaultClient: vaultClient, path: path}, nil
+}
+
+func NewTOTPWithPath(path string) (TOTP, error) {
+	vaultClient, err := api.NewClient(api.DefaultConfig())
+	if err != nil {
+		return nil, err
+	}
+	return &totpEngine{vaultClient: vaultClient, path: path}, nil
+}
@@ -0,0 +1,116 @@
+// +build integration
+
+package client_test
+
+import (
+	"github.com/hashicorp/vault/api"
+	. "github.com/jasoet/vault-client/pkg/client"
+	"github.com/stretchr/testify/assert"
+	"os"
+	"testing"
+)
+
+type totpTestCtx struct {
+	vaultClient *api.Client
+}
+
+func (ctx *totpTestCtx) setup(t *testing.T) {
+	config := &api.Config{Address: os.Getenv("TEST_VAULT_ADDR")}
+	client, err := api.NewClient(config)
+	assert.Nil(t, err)
+
+	client.SetToken(os.Getenv("TEST_VAULT_TOKEN"))
+
+	ctx.vaultClient = client
+}
+
+func TestTOTP(t *testing.T) {
+	ctx := new(totpTestCtx)
+	ctx.setup(t)
+
+	totpEnginePath := "totp-path"
+	engine, err := NewTOTP(ctx.vaultClient, totpEnginePath)
+	assert.Nil(t, err)
+	assert.NotNil(t, engine)
+
+	_ = engine.Enable()
+
+	t.Run("status should return correct result", func(t *testing.T) {
+		status, err := engine.Status()
+		assert.Nil(t, err)
+		assert.NotNil(t, status)
+	})
+
+	keyName := "mfa-user"
+	config := TOTPKeyConfig{
+		Issuer:      "vault-client",
+		AccountName: "user@example.com",
+		Period:      30,
+		Algorithm:   "SHA256",
+		Digits:      6,
+	}
+
+	var key *TOTPKey
+	t.Run("create key should return barcode and url", func(t *testing.T) {
+		key, err = engine.CreateKey(keyName, config)
+		assert.Nil(t, err)
+		assert.NotNil(t, key)
+		assert.Contains(t, key.Url, "otpauth://totp/")
+
+		png, err := key.BarcodePNG()
+		assert.Nil(t, err)
+		assert.Equal(t, []byte("\x89PNG"), png[:4])
+	})
+
+	t.Run("read key should return correct values", func(t *testing.T) {
+		detail, err := engine.ReadKey(keyName)
+		assert.Nil(t, err)
+		assert.NotNil(t, detail)
+		assert.Equal(t, config.Issuer, detail.Issuer)
+		assert.Equal(t, config.AccountName, detail.AccountName)
+		assert.Equal(t, config.Period, detail.Period)
+		assert.Equal(t, config.Algorithm, detail.Algorithm)
+		assert.Equal(t, config.Digits, detail.Digits)
+	})
+
+	t.Run("generated code should be valid once", func(t *testing.T) {
+		code, err := engine.GenerateCode(keyName)
+		assert.Nil(t, err)
+		assert.Len(t, code, 6)
+
+		valid, err := engine.ValidateCode(keyName, code)
+		assert.Nil(t, err)
+		assert.True(t, valid)
+
+		valid, _ = engine.ValidateCode(keyName, code)
+		assert.False(t, valid)
+	})
+
+	importedKeyName := "mfa-imported"
+	t.Run("imported key should generate the same code", func(t *testing.T) {
+		err := engine.ImportKey(importedKeyName, key.Url)
+		assert.Nil(t, err)
+
+		code, err := engine.GenerateCode(importedKeyName)
+		assert.Nil(t, err)
+
+		valid, err := engine.ValidateCode(keyName, code)
+		assert.Nil(t, err)
+		assert.True(t, valid)
+
+		list, err := engine.ListKey()
+		assert.Nil(t, err)
+		assert.Contains(t, list, keyName)
+		assert.Contains(t, list, importedKeyName)
+	})
+
+	t.Run("cannot fetch deleted key", func(t *testing.T) {
+		err := engine.DeleteKey(importedKeyName)
+		assert.Nil(t, err)
+
+		detail, err := engine.ReadKey(importedKeyName)
+		assert.NotNil(t, err)
+		assert.Nil(t, detail)
+	})
+
+}
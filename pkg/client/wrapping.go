@@ -0,0 +1,144 @@
+package client
+
+import (
+	"fmt"
+	"github.com/hashicorp/vault/api"
+	"github.com/jasoet/vault-client/pkg/util"
+	"time"
+)
+
+type wrappingEngine struct {
+	vaultClient *api.Client
+}
+
+func (w wrappingEngine) Wrap(input interface{}, options WrapOptions) (info *WrapInfo, err error) {
+	vaultClient, err := wrappedClient(w.vaultClient, options)
+	if err != nil {
+		return
+	}
+
+	payload, err := util.StructToMapE(input)
+	if err != nil {
+		return
+	}
+
+	result, err := vaultClient.Logical().Write("/sys/wrapping/wrap", payload)
+	if err != nil {
+		return
+	}
+
+	return toWrapInfo(result)
+}
+
+func (w wrappingEngine) Unwrap(token string, output interface{}) (err error) {
+	result, err := w.vaultClient.Logical().Unwrap(token)
+	if err != nil {
+		return
+	}
+
+	if result == nil {
+		err = fmt.Errorf("wrapped response is not found")
+		return
+	}
+
+	err = util.MapToStruct(result.Data, output)
+	return
+}
+
+func (w wrappingEngine) WrapLookup(token string) (info *WrapInfo, err error) {
+	payload := map[string]interface{}{
+		"token": token,
+	}
+	result, err := w.vaultClient.Logical().Write("/sys/wrapping/lookup", payload)
+	if err != nil {
+		return
+	}
+
+	if result == nil {
+		err = fmt.Errorf("wrapping token is not found")
+		return
+	}
+
+	lookup := new(wrapLookupDetail)
+	err = util.MapToStruct(result.Data, lookup)
+	if err != nil {
+		return
+	}
+
+	info = new(WrapInfo)
+	info.Ttl = lookup.CreationTtl
+	info.CreationTime = lookup.CreationTime
+	info.CreationPath = lookup.CreationPath
+	return
+}
+
+func (w wrappingEngine) Rewrap(token string) (info *WrapInfo, err error) {
+	payload := map[string]interface{}{
+		"token": token,
+	}
+	result, err := w.vaultClient.Logical().Write("/sys/wrapping/rewrap", payload)
+	if err != nil {
+		return
+	}
+
+	return toWrapInfo(result)
+}
+
+type wrapLookupDetail struct {
+	CreationTtl  int       `json:"creation_ttl"`
+	CreationTime time.Time `json:"creation_time"`
+	CreationPath string    `json:"creation_path"`
+}
+
+// wrappedClient returns a copy of vaultClient which asks Vault to wrap every response for options.Ttl seconds,
+// engines use it to offer wrapped variants of their read operations without touching the shared client.
+// Wrapped variants are separate methods because a wrapped response carry a WrapInfo instead of the secret data.
+func wrappedClient(vaultClient *api.Client, options WrapOptions) (wrapped *api.Client, err error) {
+	if options.Ttl <= 0 {
+		err = fmt.Errorf("wrap ttl must be greater than zero, got %v", options.Ttl)
+		return
+	}
+
+	wrapped, err = vaultClient.Clone()
+	if err != nil {
+		return
+	}
+
+	wrapped.SetToken(vaultClient.Token())
+	wrapped.SetHeaders(vaultClient.Headers())
+	wrapped.SetWrappingLookupFunc(func(operation, path string) string {
+		return fmt.Sprint(options.Ttl)
+	})
+	return
+}
+
+func toWrapInfo(secret *api.Secret) (info *WrapInfo, err error) {
+	if secret == nil || secret.WrapInfo == nil {
+		err = fmt.Errorf("response is not wrapped")
+		return
+	}
+
+	info = new(WrapInfo)
+	info.Token = secret.WrapInfo.Token
+	info.Accessor = secret.WrapInfo.Accessor
+	info.Ttl = secret.WrapInfo.TTL
+	info.CreationTime = secret.WrapInfo.CreationTime
+	info.CreationPath = secret.WrapInfo.CreationPath
+	info.WrappedAccessor = secret.WrapInfo.WrappedAccessor
+	return
+}
+
+func DefaultWrapping() (wrapping Wrapping, err error) {
+	vaultClient, err := api.NewClient(api.DefaultConfig())
+	if err != nil {
+		return
+	}
+
+	wrapping = &wrappingEngine{vaultClient: vaultClient}
+	return
+}
+
+func NewWrapping(vaultClient *api.Client) (wrapping Wrapping, err error) {
+	wrapping = &wrappingEngine{vaultClient: vaultClient}
+	return
+}
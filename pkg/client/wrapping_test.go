@@ -0,0 +1,79 @@
+// +build integration
+
+package client_test
+
+import (
+	"github.com/hashicorp/vault/api"
+	. "github.com/jasoet/vault-client/pkg/client"
+	"github.com/stretchr/testify/assert"
+	"os"
+	"testing"
+)
+
+type wrappingTestCtx struct {
+	vaultClient *api.Client
+}
+
+func (ctx *wrappingTestCtx) setup(t *testing.T) {
+	config := &api.Config{Address: os.Getenv("TEST_VAULT_ADDR")}
+	client, err := api.NewClient(config)
+	assert.Nil(t, err)
+
+	client.SetToken(os.Getenv("TEST_VAULT_TOKEN"))
+
+	ctx.vaultClient = client
+}
+
+type wrappedPayload struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+func TestWrapping(t *testing.T) {
+	ctx := new(wrappingTestCtx)
+	ctx.setup(t)
+
+	engine, err := NewWrapping(ctx.vaultClient)
+	assert.Nil(t, err)
+	assert.NotNil(t, engine)
+
+	payload := wrappedPayload{Name: "hand-off", Value: "secret value"}
+
+	t.Run("wrap with non positive ttl should return err", func(t *testing.T) {
+		info, err := engine.Wrap(payload, WrapOptions{Ttl: 0})
+		assert.NotNil(t, err)
+		assert.Nil(t, info)
+	})
+
+	t.Run("lookup should return creation info of wrapped data", func(t *testing.T) {
+		info, err := engine.Wrap(payload, WrapOptions{Ttl: 120})
+		assert.Nil(t, err)
+		assert.NotNil(t, info)
+
+		detail, err := engine.WrapLookup(info.Token)
+		assert.Nil(t, err)
+		assert.NotNil(t, detail)
+		assert.Equal(t, 120, detail.Ttl)
+		assert.Equal(t, "sys/wrapping/wrap", detail.CreationPath)
+	})
+
+	t.Run("rewrapped token should replace the old one", func(t *testing.T) {
+		info, err := engine.Wrap(payload, WrapOptions{Ttl: 120})
+		assert.Nil(t, err)
+		assert.NotNil(t, info)
+
+		rewrapped, err := engine.Rewrap(info.Token)
+		assert.Nil(t, err)
+		assert.NotNil(t, rewrapped)
+		assert.NotEqual(t, info.Token, rewrapped.Token)
+
+		output := new(wrappedPayload)
+		err = engine.Unwrap(info.Token, output)
+		assert.NotNil(t, err)
+
+		err = engine.Unwrap(rewrapped.Token, output)
+		assert.Nil(t, err)
+		assert.Equal(t, payload, *output)
+	})
+
+}
@@ -0,0 +1,52 @@
+package util
+
+import (
+	"sync"
+	"time"
+)
+
+//ParallelEach call fn for every index in [0, size) using at most `workers` goroutines.
+//When ratePerSecond is greater than zero, calls are spaced so no more than ratePerSecond calls are started every second.
+//ParallelEach returns after all calls are finished.
+func ParallelEach(size int, workers int, ratePerSecond float64, fn func(i int)) {
+	if size <= 0 {
+		return
+	}
+
+	if workers <= 0 || workers > size {
+		workers = size
+	}
+
+	var ticker *time.Ticker
+	if ratePerSecond > 0 {
+		// rates above one call per nanosecond are truncated to zero interval, NewTicker panic on it
+		interval := time.Duration(float64(time.Second) / ratePerSecond)
+		if interval < time.Nanosecond {
+			interval = time.Nanosecond
+		}
+		ticker = time.NewTicker(interval)
+		defer ticker.Stop()
+	}
+
+	indexes := make(chan int)
+	wg := sync.WaitGroup{}
+	wg.Add(workers)
+	for w := 0; w < workers; w++ {
+		go func() {
+			defer wg.Done()
+			for i := range indexes {
+				fn(i)
+			}
+		}()
+	}
+
+	for i := 0; i < size; i++ {
+		if ticker != nil && i > 0 {
+			<-ticker.C
+		}
+		indexes <- i
+	}
+	close(indexes)
+
+	wg.Wait()
+}
@@ -0,0 +1,71 @@
+package util_test
+
+import (
+	. "github.com/jasoet/vault-client/pkg/util"
+	"github.com/stretchr/testify/assert"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestParallelEach(t *testing.T) {
+	t.Run("should call fn once for every index", func(t *testing.T) {
+		size := 100
+		calls := make([]int32, size)
+
+		ParallelEach(size, 8, 0, func(i int) {
+			atomic.AddInt32(&calls[i], 1)
+		})
+
+		for i, c := range calls {
+			assert.Equal(t, int32(1), c, "index %v", i)
+		}
+	})
+
+	t.Run("should not run more than workers at the same time", func(t *testing.T) {
+		workers := 3
+		var running, maxRunning int32
+		mu := sync.Mutex{}
+
+		ParallelEach(30, workers, 0, func(i int) {
+			current := atomic.AddInt32(&running, 1)
+			mu.Lock()
+			if current > maxRunning {
+				maxRunning = current
+			}
+			mu.Unlock()
+
+			time.Sleep(5 * time.Millisecond)
+			atomic.AddInt32(&running, -1)
+		})
+
+		assert.LessOrEqual(t, maxRunning, int32(workers))
+		assert.Greater(t, maxRunning, int32(1))
+	})
+
+	t.Run("should space calls based on rate limit", func(t *testing.T) {
+		start := time.Now()
+		ParallelEach(5, 5, 50, func(i int) {})
+
+		// 5 calls at 50 per second need at least 4 intervals of 20ms
+		assert.GreaterOrEqual(t, int64(time.Since(start)), int64(80*time.Millisecond))
+	})
+
+	t.Run("should not panic on very high rate limit", func(t *testing.T) {
+		var calls int32
+		ParallelEach(5, 2, 1e12, func(i int) {
+			atomic.AddInt32(&calls, 1)
+		})
+		assert.Equal(t, int32(5), calls)
+	})
+
+	t.Run("should do nothing on empty input", func(t *testing.T) {
+		called := false
+		ParallelEach(0, 4, 0, func(i int) {
+			called = true
+		})
+		assert.False(t, called)
+	})
+
+}
@@ -1,47 +1,107 @@
 package util
 
 import (
+	"fmt"
 	"github.com/mitchellh/mapstructure"
 	"reflect"
+	"strings"
 	"time"
 )
 
 /*
 This function will help you to convert your object from struct to map[string]interface{} based on your JSON tag in your structs.
-Example how to use posted in sample_test.go file.
+Example how to use posted in sample_test.go file. Maps with string keys are copied into map[string]interface{},
+nil input return empty map, nil pointer and other types return nil, use StructToMapE to get the error.
 Credit: https://gist.github.com/bxcodec/c2a25cfc75f6b21a0492951706bc80b8
 */
 func StructToMap(item interface{}) map[string]interface{} {
+	res, _ := StructToMapE(item)
+	return res
+}
+
+//StructToMapE is StructToMap returning error for nil pointer, map with non string key and other non struct types
+func StructToMapE(item interface{}) (map[string]interface{}, error) {
 
 	res := map[string]interface{}{}
 	if item == nil {
-		return res
+		return res, nil
 	}
+
 	v := reflect.TypeOf(item)
 	reflectValue := reflect.ValueOf(item)
-	reflectValue = reflect.Indirect(reflectValue)
-
 	if v.Kind() == reflect.Ptr {
+		if reflectValue.IsNil() {
+			return nil, fmt.Errorf("can not convert nil %v to map", v)
+		}
 		v = v.Elem()
+		reflectValue = reflectValue.Elem()
 	}
+
+	switch v.Kind() {
+	case reflect.Map:
+		if v.Key().Kind() != reflect.String {
+			return nil, fmt.Errorf("can not convert %v to map, key must be string", v)
+		}
+		iterator := reflectValue.MapRange()
+		for iterator.Next() {
+			res[iterator.Key().String()] = iterator.Value().Interface()
+		}
+		return res, nil
+	case reflect.Struct:
+	default:
+		return nil, fmt.Errorf("can not convert %v to map", v)
+	}
+
 	for i := 0; i < v.NumField(); i++ {
-		tag := v.Field(i).Tag.Get("json")
+		tag, omitEmpty := parseJsonTag(v.Field(i).Tag.Get("json"))
+		if omitEmpty && reflectValue.Field(i).IsZero() {
+			continue
+		}
+
 		field := reflectValue.Field(i).Interface()
+		if tag == "" && v.Field(i).Anonymous && v.Field(i).Type.Kind() == reflect.Struct {
+			// untagged embedded struct, its fields are promoted like `encoding/json` does
+			promoted, err := StructToMapE(field)
+			if err != nil {
+				return nil, err
+			}
+			for key, value := range promoted {
+				res[key] = value
+			}
+			continue
+		}
+
 		if tag != "" && tag != "-" {
 			if v.Field(i).Type.Kind() == reflect.Struct {
-				res[tag] = StructToMap(field)
+				nested, err := StructToMapE(field)
+				if err != nil {
+					return nil, err
+				}
+				res[tag] = nested
 			} else {
 				res[tag] = field
 			}
 		}
 	}
-	return res
+	return res, nil
+}
+
+//parseJsonTag split `json` tag into field name and whether `omitempty` option is set
+func parseJsonTag(tag string) (name string, omitEmpty bool) {
+	parts := strings.Split(tag, ",")
+	for _, option := range parts[1:] {
+		if option == "omitempty" {
+			omitEmpty = true
+		}
+	}
+	return parts[0], omitEmpty
 }
 
-//MapToStruct used to convert Map to Struct, mapping uses `json` tag, will also decode string to time with `time.RFC3339Nano` layout
+//MapToStruct used to convert Map to Struct, mapping uses `json` tag, will also decode string to time with `time.RFC3339Nano` layout, empty string is decoded as nil *time.Time
+//Untagged embedded structs are squashed, their fields are decoded from the same level as the parent fields.
 //See https://github.com/mitchellh/mapstructure/blob/master/mapstructure_test.go for mapstructure library usage example.
 func MapToStruct(input interface{}, result interface{}) (err error) {
-	config := &mapstructure.DecoderConfig{TagName: "json", Result: result, DecodeHook: mapstructure.StringToTimeHookFunc(time.RFC3339Nano)}
+	config := &mapstructure.DecoderConfig{TagName: "json", Result: result, Squash: true, DecodeHook: stringToTimeHookFunc(time.RFC3339Nano)}
 	decoder, err := mapstructure.NewDecoder(config)
 	if err != nil {
 		return
@@ -49,3 +109,25 @@ func MapToStruct(input interface{}, result interface{}) (err error) {
 
 	return decoder.Decode(input)
 }
+
+//stringToTimeHookFunc decode string as time.Time, empty string is decoded as nil *time.Time
+//because Vault return "" for unset time such as deletion_time
+func stringToTimeHookFunc(layout string) mapstructure.DecodeHookFuncType {
+	return func(from reflect.Type, to reflect.Type, data interface{}) (interface{}, error) {
+		if from.Kind() != reflect.String {
+			return data, nil
+		}
+
+		switch to {
+		case reflect.TypeOf(&time.Time{}):
+			if data.(string) == "" {
+				return nil, nil
+			}
+			return data, nil
+		case reflect.TypeOf(time.Time{}):
+			return time.Parse(layout, data.(string))
+		default:
+			return data, nil
+		}
+	}
+}
@@ -6,6 +6,7 @@ import (
 	. "github.com/jasoet/vault-client/pkg/util"
 	"github.com/stretchr/testify/require"
 	"testing"
+	"time"
 )
 
 type SampleStruct struct {
@@ -87,3 +88,135 @@ func TestStructToMap_EmbeddedStruct(t *testing.T) {
 	fmt.Println(string(jbyt))
 	// Output: {"field":{"one_point":"yuhuhuu","sample":{"name":"John Doe","id":"12121"}},"hello":"WORLD!!!!"}
 }
+
+type OptionalStruct struct {
+	Name       string   `json:"name"`
+	Statements []string `json:"statements,omitempty"`
+	Policy     string   `json:"policy,omitempty"`
+	Ignored    string   `json:"-"`
+}
+
+func TestStructToMap_OmitEmpty(t *testing.T) {
+	t.Run("should use field name without tag options", func(t *testing.T) {
+		res, err := StructToMapE(OptionalStruct{Name: "John Doe", Statements: []string{"one"}, Policy: "default"})
+		require.NoError(t, err)
+		require.Equal(t, map[string]interface{}{
+			"name":       "John Doe",
+			"statements": []string{"one"},
+			"policy":     "default",
+		}, res)
+	})
+
+	t.Run("should skip empty fields with omitempty", func(t *testing.T) {
+		res, err := StructToMapE(OptionalStruct{Ignored: "ignored"})
+		require.NoError(t, err)
+		require.Equal(t, map[string]interface{}{
+			"name": "",
+		}, res)
+	})
+}
+
+type BaseStruct struct {
+	Host string `json:"host"`
+	Port int    `json:"port,omitempty"`
+}
+
+type PromotedStruct struct {
+	BaseStruct
+	Tls bool `json:"tls"`
+}
+
+func TestStructToMap_PromotedStruct(t *testing.T) {
+	res, err := StructToMapE(PromotedStruct{BaseStruct: BaseStruct{Host: "localhost", Port: 6379}, Tls: true})
+	require.NoError(t, err)
+	require.Equal(t, map[string]interface{}{
+		"host": "localhost",
+		"port": 6379,
+		"tls":  true,
+	}, res)
+}
+
+func TestMapToStruct_PromotedStruct(t *testing.T) {
+	input := map[string]interface{}{
+		"host": "localhost",
+		"port": 6379,
+		"tls":  true,
+	}
+
+	result := new(PromotedStruct)
+	err := MapToStruct(input, result)
+	require.NoError(t, err)
+	require.Equal(t, PromotedStruct{BaseStruct: BaseStruct{Host: "localhost", Port: 6379}, Tls: true}, *result)
+}
+
+type TimeStruct struct {
+	CreatedTime  time.Time  `json:"created_time"`
+	DeletionTime *time.Time `json:"deletion_time"`
+}
+
+func TestMapToStruct_EmptyTime(t *testing.T) {
+	input := map[string]interface{}{
+		"created_time":  "2020-01-02T03:04:05.123456Z",
+		"deletion_time": "",
+	}
+
+	result := new(TimeStruct)
+	err := MapToStruct(input, result)
+	require.NoError(t, err)
+	require.Equal(t, time.Date(2020, 1, 2, 3, 4, 5, 123456000, time.UTC), result.CreatedTime)
+	require.Nil(t, result.DeletionTime)
+
+	input["deletion_time"] = "2020-01-03T03:04:05Z"
+	err = MapToStruct(input, result)
+	require.NoError(t, err)
+	require.Equal(t, time.Date(2020, 1, 3, 3, 4, 5, 0, time.UTC), *result.DeletionTime)
+}
+
+func TestStructToMap_Map(t *testing.T) {
+	t.Run("should copy maps with string key", func(t *testing.T) {
+		input := map[string]interface{}{"host": "localhost", "port": 6379}
+		res, err := StructToMapE(input)
+		require.NoError(t, err)
+		require.Equal(t, input, res)
+
+		res, err = StructToMapE(&input)
+		require.NoError(t, err)
+		require.Equal(t, input, res)
+
+		res, err = StructToMapE(map[string]string{"host": "localhost"})
+		require.NoError(t, err)
+		require.Equal(t, map[string]interface{}{"host": "localhost"}, res)
+	})
+
+	t.Run("should return error for map with non string key", func(t *testing.T) {
+		_, err := StructToMapE(map[int]string{1: "one"})
+		require.Error(t, err)
+	})
+}
+
+func TestStructToMap_Invalid(t *testing.T) {
+	t.Run("should return nil without error", func(t *testing.T) {
+		require.Nil(t, StructToMap("value"))
+		require.Empty(t, StructToMap(nil))
+	})
+
+	t.Run("should return empty map for nil", func(t *testing.T) {
+		res, err := StructToMapE(nil)
+		require.NoError(t, err)
+		require.Empty(t, res)
+	})
+
+	t.Run("should return error for nil pointer", func(t *testing.T) {
+		var sample *SampleStruct
+		_, err := StructToMapE(sample)
+		require.Error(t, err)
+	})
+
+	t.Run("should return error for non struct", func(t *testing.T) {
+		_, err := StructToMapE("value")
+		require.Error(t, err)
+
+		_, err = StructToMapE([]string{"value"})
+		require.Error(t, err)
+	})
+}
@@ -39,12 +39,11 @@ func main() {
 	config, err := database.ReadConnection(connectionName)
 	fmt.Printf("ReadConnection: %#v\n", config)
 
-	roleConfig := client.DatabaseRole{
-		ConnectionName:       connectionName,
-		DefaultTtl:           60,
-		MaxTtl:               600,
-		CreationStatements:   []string{"CREATE USER '{{name}}'@'%' IDENTIFIED BY '{{password}}';", "GRANT SELECT ON *.* TO '{{name}}'@'%';"},
-		RevocationStatements: []string{"DROP USER '{{name}}'@'%';"},
+	roleConfig, err := client.NewMySQLRole(connectionName, client.Grant{Privileges: []string{"SELECT"}}).
+		WithTtl(60, 600).
+		Build()
+	if err != nil {
+		panic(err)
 	}
 
 	err = database.CreateRole(roleName, roleConfig)